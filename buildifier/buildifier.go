@@ -23,15 +23,18 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
 
 	"github.com/bazelbuild/buildtools/build"
 	"github.com/bazelbuild/buildtools/buildifier/utils"
+	"github.com/bazelbuild/buildtools/builtins"
 	"github.com/bazelbuild/buildtools/differ"
 	"github.com/bazelbuild/buildtools/tables"
 	"github.com/bazelbuild/buildtools/warn"
+	"github.com/bazelbuild/buildtools/warn/plugins"
 	"github.com/bazelbuild/buildtools/wspace"
 )
 
@@ -52,6 +55,14 @@ var (
 	filePath      = flag.String("path", "", "assume BUILD file has this path relative to the workspace directory")
 	tablesPath    = flag.String("tables", "", "path to JSON file with custom table definitions which will replace the built-in tables")
 	addTablesPath = flag.String("add_tables", "", "path to JSON file with custom table definitions which will be merged with the built-in tables")
+	builtinsPath  = flag.String("builtins", "", "path to textproto file with signatures of builtin functions which will replace the built-in signatures")
+	addBuiltins   = flag.String("add_builtins", "", "path to textproto file with signatures of builtin functions which will be merged with the built-in signatures")
+	policyPath    = flag.String("policy", "", "path to JSON file with declarative policies checked by the \"policy\" warning")
+	layeringPath  = flag.String("layering", "", "path to JSON file with rules for dependencies between packages checked by the \"layering\" warning")
+	visibilityFix = flag.Bool("visibility_fix_commands", false, "suggest buildozer commands that fix the violations reported by the \"target-visibility\" warning")
+	lintPlugins   = flag.String("lint_plugins", "", "comma-separated list of Starlark files with custom lint warnings")
+	baselinePath  = flag.String("baseline", "", "path to JSON file with known lint findings that shouldn't be reported (only with -lint=warn)")
+	baselineMode  = flag.String("baseline_mode", "check", "baseline mode: check (report findings that are not in the baseline), update (record all current findings), or prune (remove fixed findings from the baseline)")
 	version       = flag.Bool("version", false, "Print the version of buildifier")
 	inputType     = flag.String("type", "auto", "Input file type: build (for BUILD files), bzl (for .bzl files), workspace (for WORKSPACE files), default (for generic Starlark files) or auto (default, based on the filename)")
 
@@ -149,6 +160,18 @@ func main() {
 		os.Exit(2)
 	}
 
+	if err := utils.ValidateBaseline(baselinePath, baselineMode, lint); err != nil {
+		fmt.Fprintf(os.Stderr, "buildifier: %s\n", err)
+		os.Exit(2)
+	}
+
+	if *lintPlugins != "" {
+		if _, err := plugins.LoadPlugins(strings.Split(*lintPlugins, ",")); err != nil {
+			fmt.Fprintf(os.Stderr, "buildifier: failed to load -lint_plugins: %s\n", err)
+			os.Exit(2)
+		}
+	}
+
 	warningsList, err := utils.ValidateWarnings(warnings, &warn.AllWarnings, &warn.DefaultWarnings)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "buildifier: %s\n", err)
@@ -176,6 +199,44 @@ func main() {
 		}
 	}
 
+	if *builtinsPath != "" {
+		if err := builtins.ParseAndUpdate(*builtinsPath, false); err != nil {
+			fmt.Fprintf(os.Stderr, "buildifier: failed to parse %s for -builtins: %s\n", *builtinsPath, err)
+			os.Exit(2)
+		}
+	}
+
+	if *addBuiltins != "" {
+		if err := builtins.ParseAndUpdate(*addBuiltins, true); err != nil {
+			fmt.Fprintf(os.Stderr, "buildifier: failed to parse %s for -add_builtins: %s\n", *addBuiltins, err)
+			os.Exit(2)
+		}
+	}
+
+	if *policyPath != "" {
+		if err := warn.ParsePolicyFile(*policyPath); err != nil {
+			fmt.Fprintf(os.Stderr, "buildifier: failed to parse %s for -policy: %s\n", *policyPath, err)
+			os.Exit(2)
+		}
+	}
+
+	if *layeringPath != "" {
+		if err := warn.ParseLayeringFile(*layeringPath); err != nil {
+			fmt.Fprintf(os.Stderr, "buildifier: failed to parse %s for -layering: %s\n", *layeringPath, err)
+			os.Exit(2)
+		}
+	}
+
+	warn.SetVisibilityFixCommands(*visibilityFix)
+
+	if *baselinePath != "" {
+		var err error
+		if baseline, err = utils.LoadBaseline(*baselinePath); err != nil {
+			fmt.Fprintf(os.Stderr, "buildifier: failed to read -baseline: %s\n", err)
+			os.Exit(2)
+		}
+	}
+
 	differ, deprecationWarning := differ.Find()
 	if *diffProgram != "" {
 		differ.Cmd = *diffProgram
@@ -239,6 +300,13 @@ func run(args, warningsList *[]string) int {
 		return 2
 	}
 
+	if baseline != nil && *baselineMode != utils.BaselineCheck {
+		if err := baseline.Save(*baselinePath); err != nil {
+			fmt.Fprintf(os.Stderr, "buildifier: failed to write -baseline: %s\n", err)
+			return 3
+		}
+	}
+
 	return exitCode
 }
 
@@ -305,6 +373,18 @@ func processFiles(files []string, inputType, lint string, warningsList *[]string
 // diff is the differ to use when *mode == "diff".
 var diff *differ.Differ
 
+// baseline contains the known findings that shouldn't be reported, if the -baseline flag is set.
+var baseline *utils.Baseline
+
+// baselineFilename returns the name of a file used in the baseline, it's relative to
+// the workspace root if it's known so that the baseline doesn't depend on the working directory.
+func baselineFilename(f *build.File) string {
+	if f.WorkspaceRoot == "" || f.Path == "" {
+		return f.DisplayPath()
+	}
+	return path.Join(f.Pkg, filepath.Base(f.Path))
+}
+
 // processFile processes a single file containing data.
 // It has been read from filename and should be written back if fixing.
 func processFile(filename string, data []byte, inputType, lint string, warningsList *[]string, displayFileNames bool, tf *utils.TempFile) (*utils.FileDiagnostics, int) {
@@ -334,6 +414,9 @@ func processFile(filename string, data []byte, inputType, lint string, warningsL
 	}
 
 	warnings := utils.Lint(f, lint, warningsList, *vflag)
+	if baseline != nil {
+		warnings = baseline.Apply(baselineFilename(f), data, warnings, *baselineMode)
+	}
 	if len(warnings) > 0 {
 		exitCode = 4
 	}
@@ -0,0 +1,167 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/warn"
+)
+
+// Baseline modes
+const (
+	BaselineCheck  = "check"  // report only findings that are not in the baseline
+	BaselineUpdate = "update" // record all current findings in the baseline
+	BaselinePrune  = "prune"  // remove findings that don't exist anymore from the baseline
+)
+
+// BaselineEntry is a group of identical known findings in a file.
+type BaselineEntry struct {
+	File        string `json:"file"`
+	Category    string `json:"category"`
+	Fingerprint string `json:"fingerprint"`
+	Count       int    `json:"count"`
+}
+
+// Baseline is a set of known findings that shouldn't be reported. Findings are identified
+// by their file, category and a fingerprint of the message and the source code
+// they're reported for, so they're not affected by unrelated changes that shift lines.
+type Baseline struct {
+	Findings []BaselineEntry `json:"findings"`
+
+	counts map[baselineKey]int
+}
+
+type baselineKey struct {
+	file, category, fingerprint string
+}
+
+var digitsRegexp = regexp.MustCompile(`[0-9]+`)
+
+// fingerprint computes a fingerprint of a finding that doesn't depend on its position in the file.
+func fingerprint(data []byte, finding *warn.Finding) string {
+	lines := strings.Split(string(data), "\n")
+	var source []string
+	for i := finding.Start.Line; i <= finding.End.Line && i <= len(lines); i++ {
+		if i > 0 {
+			source = append(source, strings.Join(strings.Fields(lines[i-1]), " "))
+		}
+	}
+	// Messages may contain line numbers of other statements
+	message := digitsRegexp.ReplaceAllString(finding.Message, "#")
+
+	hash := sha256.Sum256([]byte(message + "\x00" + strings.Join(source, "\n")))
+	return hex.EncodeToString(hash[:8])
+}
+
+// NewBaseline returns an empty baseline.
+func NewBaseline() *Baseline {
+	return &Baseline{counts: make(map[baselineKey]int)}
+}
+
+// LoadBaseline reads a baseline from a JSON file. A missing file is treated as an empty baseline.
+func LoadBaseline(path string) (*Baseline, error) {
+	b := NewBaseline()
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return b, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	for _, e := range b.Findings {
+		b.counts[baselineKey{e.File, e.Category, e.Fingerprint}] += e.Count
+	}
+	return b, nil
+}
+
+// Save writes the baseline to a JSON file, the entries are sorted to produce stable diffs.
+func (b *Baseline) Save(path string) error {
+	b.Findings = nil
+	for key, count := range b.counts {
+		if count > 0 {
+			b.Findings = append(b.Findings, BaselineEntry{key.file, key.category, key.fingerprint, count})
+		}
+	}
+	sort.Slice(b.Findings, func(i, j int) bool {
+		x, y := b.Findings[i], b.Findings[j]
+		if x.File != y.File {
+			return x.File < y.File
+		}
+		if x.Category != y.Category {
+			return x.Category < y.Category
+		}
+		return x.Fingerprint < y.Fingerprint
+	})
+	if b.Findings == nil {
+		b.Findings = []BaselineEntry{}
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0666)
+}
+
+// Apply updates the baseline with the findings of a file according to the mode and returns
+// the findings that are not in the baseline. In the update mode all current findings are recorded
+// (and no findings are returned), in the prune mode the entries of the file that don't match any
+// current finding are removed. The data is the content of the file the findings are reported for.
+func (b *Baseline) Apply(file string, data []byte, findings []*warn.Finding, mode string) []*warn.Finding {
+	current := make(map[baselineKey]int)
+	keys := make([]baselineKey, len(findings))
+	for i, f := range findings {
+		keys[i] = baselineKey{file, f.Category, fingerprint(data, f)}
+		current[keys[i]]++
+	}
+
+	switch mode {
+	case BaselineUpdate, BaselinePrune:
+		for key := range b.counts {
+			if key.file == file && current[key] == 0 {
+				delete(b.counts, key)
+			}
+		}
+		for key, count := range current {
+			if mode == BaselineUpdate || count < b.counts[key] {
+				b.counts[key] = count
+			}
+		}
+	}
+
+	// Identical findings are only suppressed as many times as they're recorded
+	used := make(map[baselineKey]int)
+	var result []*warn.Finding
+	for i, f := range findings {
+		if used[keys[i]] < b.counts[keys[i]] {
+			used[keys[i]]++
+			continue
+		}
+		result = append(result, f)
+	}
+	return result
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/warn"
+)
+
+func makeFinding(category, message string, line int) *warn.Finding {
+	return &warn.Finding{
+		Start:    build.Position{Line: line},
+		End:      build.Position{Line: line},
+		Category: category,
+		Message:  message,
+	}
+}
+
+func lines(findings []*warn.Finding) []int {
+	var result []int
+	for _, f := range findings {
+		result = append(result, f.Start.Line)
+	}
+	return result
+}
+
+func equalInts(x, y []int) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBaseline(t *testing.T) {
+	dir, err := ioutil.TempDir(os.Getenv("TEST_TMPDIR"), "baseline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "baseline.json")
+
+	b, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("LoadBaseline() of a missing file returned an error: %v", err)
+	}
+	data := []byte("foo(\"a\")\nfoo(\"a\")\nbar(\"b\")  # line 3\n")
+	findings := []*warn.Finding{
+		makeFinding("positional-args", "Positional args, see line 2.", 1),
+		makeFinding("positional-args", "Positional args, see line 2.", 2),
+		makeFinding("positional-args", "Positional args, see line 2.", 3),
+	}
+	if got := b.Apply("pkg/BUILD", data, findings, BaselineUpdate); len(got) != 0 {
+		t.Errorf("Apply() in the update mode returned findings on lines %v", lines(got))
+	}
+	if err := b.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err = LoadBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Findings) != 2 {
+		t.Errorf("got %d baseline entries, want 2: %v", len(b.Findings), b.Findings)
+	}
+
+	// Lines are shifted, the message changes its line number, one identical finding is added
+	data = []byte("# comment\nfoo(\"a\")\nfoo(\"a\")\n    foo(\"a\")\nbar(\"b\")  # line 3\nbaz(\"c\")\n")
+	findings = []*warn.Finding{
+		makeFinding("positional-args", "Positional args, see line 3.", 2),
+		makeFinding("positional-args", "Positional args, see line 3.", 3),
+		makeFinding("positional-args", "Positional args, see line 3.", 4),
+		makeFinding("positional-args", "Positional args, see line 3.", 5),
+		makeFinding("positional-args", "Positional args, see line 3.", 6),
+		makeFinding("other-category", "Positional args, see line 3.", 6),
+	}
+	if got, want := lines(b.Apply("pkg/BUILD", data, findings, BaselineCheck)), []int{4, 6, 6}; !equalInts(got, want) {
+		t.Errorf("Apply() returned findings on lines %v, want %v", got, want)
+	}
+	if got, want := lines(b.Apply("other/BUILD", data, findings[:1], BaselineCheck)), []int{2}; !equalInts(got, want) {
+		t.Errorf("Apply() for another file returned findings on lines %v, want %v", got, want)
+	}
+
+	// Pruning removes fixed findings but doesn't add new ones
+	data = []byte("foo(\"a\")\nbaz(\"c\")\n")
+	findings = []*warn.Finding{
+		makeFinding("positional-args", "Positional args, see line 3.", 1),
+		makeFinding("positional-args", "Positional args, see line 3.", 2),
+	}
+	if got, want := lines(b.Apply("pkg/BUILD", data, findings, BaselinePrune)), []int{2}; !equalInts(got, want) {
+		t.Errorf("Apply() in the prune mode returned findings on lines %v, want %v", got, want)
+	}
+	if err := b.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Findings) != 1 || b.Findings[0].Count != 1 {
+		t.Errorf("got baseline entries %v after pruning, want a single entry", b.Findings)
+	}
+}
+
+func TestValidateBaseline(t *testing.T) {
+	for _, tc := range []struct {
+		baseline, mode, lint string
+		ok                   bool
+	}{
+		{"", "", "off", true},
+		{"baseline.json", "update", "warn", true},
+		{"baseline.json", "check", "fix", false},
+		{"baseline.json", "remove", "warn", false},
+	} {
+		err := ValidateBaseline(&tc.baseline, &tc.mode, &tc.lint)
+		if (err == nil) != tc.ok {
+			t.Errorf("ValidateBaseline(%q, %q, %q) returned %v", tc.baseline, tc.mode, tc.lint, err)
+		}
+	}
+}
@@ -139,3 +139,20 @@ func ValidateWarnings(warnings *string, allWarnings, defaultWarnings *[]string)
 	}
 	return warningsList, nil
 }
+
+// ValidateBaseline validates the values of --baseline and --baseline_mode
+func ValidateBaseline(baseline, baselineMode, lint *string) error {
+	switch *baselineMode {
+	case "":
+		*baselineMode = BaselineCheck
+	case BaselineCheck, BaselineUpdate, BaselinePrune:
+		// ok
+	default:
+		return fmt.Errorf("unrecognized baseline mode %s; valid modes are check, update, prune", *baselineMode)
+	}
+
+	if *baseline != "" && *lint != "warn" {
+		return fmt.Errorf("--baseline is only compatible with --lint=warn")
+	}
+	return nil
+}
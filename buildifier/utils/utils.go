@@ -23,9 +23,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/labels"
 	"github.com/bazelbuild/buildtools/warn"
+	"github.com/bazelbuild/buildtools/wspace"
 )
 
 func isStarlarkFile(name string) bool {
@@ -98,6 +101,25 @@ func GetParser(inputType string) func(filename string, data []byte) (*build.File
 	}
 }
 
+var (
+	repoMappingsMu sync.Mutex
+	repoMappings   = make(map[string]*labels.RepoMapping)
+)
+
+// getRepoMapping returns the repository mapping of the main repository declared in the
+// MODULE.bazel file in the workspace root, or nil if there's no such file. The mappings
+// are cached by the workspace roots.
+func getRepoMapping(workspaceRoot string) *labels.RepoMapping {
+	repoMappingsMu.Lock()
+	defer repoMappingsMu.Unlock()
+	mapping, ok := repoMappings[workspaceRoot]
+	if !ok {
+		mapping = wspace.FindModule(workspaceRoot).RepoMapping()
+		repoMappings[workspaceRoot] = mapping
+	}
+	return mapping
+}
+
 // getFileReader returns a *FileReader object that reads files from the local
 // filesystem if the workspace root is known.
 func getFileReader(workspaceRoot string) *warn.FileReader {
@@ -105,15 +127,22 @@ func getFileReader(workspaceRoot string) *warn.FileReader {
 		return nil
 	}
 
-	readFile := func(filename string) ([]byte, error) {
+	localPath := func(filename string) string {
 		// Use OS-specific path separators
 		filename = strings.ReplaceAll(filename, "/", string(os.PathSeparator))
-		path := filepath.Join(workspaceRoot, filename)
-
-		return ioutil.ReadFile(path)
+		return filepath.Join(workspaceRoot, filename)
+	}
+	readFile := func(filename string) ([]byte, error) {
+		return ioutil.ReadFile(localPath(filename))
+	}
+	statFile := func(filename string) (os.FileInfo, error) {
+		return os.Stat(localPath(filename))
 	}
 
-	return warn.NewFileReader(readFile)
+	fileReader := warn.NewFileReader(readFile)
+	fileReader.SetStatFile(statFile)
+	fileReader.SetRepoMapping(getRepoMapping(workspaceRoot))
+	return fileReader
 }
 
 // Lint calls the linter and returns a list of unresolved findings
@@ -21,12 +21,19 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/bazelbuild/buildtools/build"
 	"github.com/bazelbuild/buildtools/edit"
+	"github.com/bazelbuild/buildtools/labels"
+	"github.com/bazelbuild/buildtools/lang"
 	"github.com/bazelbuild/buildtools/tables"
+	"github.com/bazelbuild/buildtools/warn"
+	"github.com/bazelbuild/buildtools/wspace"
 )
 
 type flagArray []string
@@ -58,6 +65,8 @@ var (
 	isPrintingJSON    = flag.Bool("output_json", false, "output serialized devtools.buildozer.Output json instead of human-readable strings.")
 	tablesPath        = flag.String("tables", "", "path to JSON file with custom table definitions which will replace the built-in tables")
 	addTablesPath     = flag.String("add_tables", "", "path to JSON file with custom table definitions which will be merged with the built-in tables")
+	macroSuffixes     = stringList("macro_suffixes", "comma-separated list of <kind>=<suffix> pairs describing the targets generated by macros, e.g. my_macro=_lib,my_macro=_test")
+	loadRuleSchemas   = flag.Bool("rule_schemas", false, "use the attribute types of Starlark rules and macros loaded by BUILD files")
 
 	shortenLabelsFlag  = flag.Bool("shorten_labels", true, "convert added labels to short form, e.g. //foo:bar => :bar")
 	deleteWithComments = flag.Bool("delete_with_comments", true, "If a list attribute should be deleted even if there is a comment attached to it")
@@ -77,6 +86,51 @@ func stringList(name, help string) func() []string {
 	}
 }
 
+// ruleSchemas returns a function that extracts the attribute schemas of Starlark rules and
+// macros available in a BUILD file. The loaded .bzl files are read relatively to the
+// workspace root of the BUILD file and cached.
+func ruleSchemas() func(f *build.File) map[string]map[string]lang.AttributeSchema {
+	var mu sync.Mutex
+	fileReaders := make(map[string]*warn.FileReader)
+	return func(f *build.File) map[string]map[string]lang.AttributeSchema {
+		mu.Lock()
+		defer mu.Unlock()
+		root := f.WorkspaceRoot
+		if root == "" {
+			return warn.RuleSchemas(f, nil)
+		}
+		fileReader, ok := fileReaders[root]
+		if !ok {
+			fileReader = warn.NewFileReader(func(filename string) ([]byte, error) {
+				return ioutil.ReadFile(filepath.Join(root, filename))
+			})
+			fileReaders[root] = fileReader
+		}
+		return warn.RuleSchemas(f, fileReader)
+	}
+}
+
+// repoMappings returns a function that returns the repository mapping of the main repository
+// of the workspace a file belongs to, parsed from its MODULE.bazel file and cached.
+func repoMappings() func(f *build.File) *labels.RepoMapping {
+	var mu sync.Mutex
+	mappings := make(map[string]*labels.RepoMapping)
+	return func(f *build.File) *labels.RepoMapping {
+		mu.Lock()
+		defer mu.Unlock()
+		root := f.WorkspaceRoot
+		if root == "" {
+			return nil
+		}
+		mapping, ok := mappings[root]
+		if !ok {
+			mapping = wspace.FindModule(root).RepoMapping()
+			mappings[root] = mapping
+		}
+		return mapping
+	}
+}
+
 func main() {
 	flag.Var(&commandsFiles, "f", "file name(s) to read commands from, use '-' for stdin (format:|-separated command line arguments to buildozer, excluding flags)")
 	flag.Parse()
@@ -101,6 +155,12 @@ func main() {
 		}
 	}
 
+	suffixes, err := edit.ParseMacroSuffixes(macroSuffixes())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "buildozer: %s\n", err)
+		os.Exit(2)
+	}
+
 	if !(*shortenLabelsFlag) {
 		build.DisableRewrites = []string{"label"}
 	}
@@ -120,6 +180,11 @@ func main() {
 		EditVariables:     *editVariables,
 		IsPrintingProto:   *isPrintingProto,
 		IsPrintingJSON:    *isPrintingJSON,
+		MacroSuffixes:     suffixes,
+		RepoMapping:       repoMappings(),
+	}
+	if *loadRuleSchemas {
+		opts.RuleSchemas = ruleSchemas()
 	}
 	os.Exit(edit.Buildozer(opts, flag.Args()))
 }
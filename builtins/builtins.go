@@ -0,0 +1,132 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package builtins describes the signatures of Bazel builtin functions.
+package builtins
+
+import (
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/bazelbuild/buildtools/builtins_proto"
+)
+
+// Database contains the signatures of builtin functions by their fully qualified names.
+type Database struct {
+	BazelVersion string
+	functions    map[string]*pb.Function
+}
+
+// Parse parses a database from its textproto representation.
+func Parse(data []byte) (*Database, error) {
+	builtins := &pb.Builtins{}
+	if err := proto.UnmarshalText(string(data), builtins); err != nil {
+		return nil, err
+	}
+	db := &Database{
+		BazelVersion: builtins.BazelVersion,
+		functions:    make(map[string]*pb.Function),
+	}
+	for _, fn := range builtins.Function {
+		db.functions[fn.Name] = fn
+	}
+	return db, nil
+}
+
+// Load reads a database from a textproto file.
+func Load(file string) (*Database, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Function returns the signature of a function, or nil if the function is unknown.
+func (db *Database) Function(name string) *pb.Function {
+	return db.functions[name]
+}
+
+// Param returns a parameter of a function by its name, or nil.
+func Param(fn *pb.Function, name string) *pb.Param {
+	for _, param := range fn.Param {
+		if param.Name == name {
+			return param
+		}
+	}
+	return nil
+}
+
+// defaultDatabase is the database embedded in the binary, it's not affected by ParseAndUpdate.
+var defaultDatabase *Database
+
+// current is the database used by Lookup.
+var current *Database
+
+func init() {
+	db, err := Parse([]byte(defaultBuiltins))
+	if err != nil {
+		panic(err)
+	}
+	defaultDatabase = db
+	current = db
+}
+
+// Default returns the database embedded in the binary, regardless of the databases
+// loaded by ParseAndUpdate.
+func Default() *Database {
+	return defaultDatabase
+}
+
+// Lookup returns the signature of a builtin function by its fully qualified name,
+// e.g. "rule", "attr.label_list" or "ctx.actions.run", or nil if the function is unknown.
+func Lookup(name string) *pb.Function {
+	return current.Function(name)
+}
+
+// Reset restores the default database, discarding the changes made by ParseAndUpdate.
+func Reset() {
+	current = defaultDatabase
+}
+
+// ParseAndUpdate reads a database from a textproto file and merges it with or
+// overrides the database in memory.
+func ParseAndUpdate(file string, merge bool) error {
+	db, err := Load(file)
+	if err != nil {
+		return err
+	}
+	if !merge {
+		current = db
+		return nil
+	}
+	merged := &Database{
+		BazelVersion: current.BazelVersion,
+		functions:    make(map[string]*pb.Function),
+	}
+	if db.BazelVersion != "" {
+		merged.BazelVersion = db.BazelVersion
+	}
+	for name, fn := range current.functions {
+		merged.functions[name] = fn
+	}
+	for name, fn := range db.functions {
+		merged.functions[name] = fn
+	}
+	current = merged
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builtins
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultBuiltins(t *testing.T) {
+	fn := Lookup("attr.label")
+	if fn == nil {
+		t.Fatal(`no signature for "attr.label"`)
+	}
+	param := Param(fn, "single_file")
+	if param == nil || param.Deprecation.GetRenamedTo() != "allow_single_file" {
+		t.Errorf(`"single_file" should be renamed to "allow_single_file", got %v`, param)
+	}
+	if Param(fn, "unknown") != nil {
+		t.Error(`"attr.label" shouldn't have a parameter "unknown"`)
+	}
+
+	fn = Lookup("ctx.actions.run")
+	if fn == nil {
+		t.Fatal(`no signature for "ctx.actions.run"`)
+	}
+	if param := Param(fn, "outputs"); param == nil || !param.Required {
+		t.Errorf(`"outputs" should be a required parameter of "ctx.actions.run", got %v`, param)
+	}
+
+	if Lookup("unknown_function") != nil {
+		t.Error(`"unknown_function" shouldn't be known`)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	if _, err := Parse([]byte(`function { name: "foo" unknown_field: 1 }`)); err == nil {
+		t.Error("expected a parse error")
+	}
+}
+
+func TestParseAndUpdate(t *testing.T) {
+	defer func(db *Database) { current = db }(current)
+
+	dir, err := ioutil.TempDir(os.Getenv("TEST_TMPDIR"), "builtins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "builtins.textproto")
+	data := `
+bazel_version: "1.2.3"
+
+function {
+  name: "rule"
+  param { name: "impl" required: true positional: true }
+}
+
+function {
+  name: "my_function"
+  kwargs: true
+}
+`
+	if err := ioutil.WriteFile(file, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ParseAndUpdate(file, true); err != nil {
+		t.Fatal(err)
+	}
+	if current.BazelVersion != "1.2.3" {
+		t.Errorf("got version %q, want %q", current.BazelVersion, "1.2.3")
+	}
+	if fn := Lookup("rule"); fn == nil || Param(fn, "impl") == nil || Param(fn, "implementation") != nil {
+		t.Errorf(`"rule" should be overridden, got %v`, fn)
+	}
+	if fn := Lookup("my_function"); fn == nil || !fn.Kwargs {
+		t.Errorf(`"my_function" should be added, got %v`, fn)
+	}
+	if Lookup("attr.label") == nil {
+		t.Error(`"attr.label" should be kept after merging`)
+	}
+
+	if err := ParseAndUpdate(file, false); err != nil {
+		t.Fatal(err)
+	}
+	if Lookup("attr.label") != nil {
+		t.Error(`"attr.label" should be removed after replacing`)
+	}
+	if Default().Function("attr.label") == nil {
+		t.Error(`"attr.label" should be kept in the default database`)
+	}
+
+	Reset()
+	if Lookup("attr.label") == nil {
+		t.Error(`"attr.label" should be restored after resetting`)
+	}
+}
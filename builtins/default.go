@@ -0,0 +1,830 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builtins
+
+// defaultBuiltins contains the signatures of Bazel builtin functions in the textproto
+// format of the Builtins message defined in builtins_proto/builtins.proto.
+const defaultBuiltins = `bazel_version: "7.0.0"
+
+function {
+  name: "rule"
+  param { name: "implementation" type: "function" required: true positional: true }
+  param { name: "test" type: "bool" default_value: "unbound" }
+  param { name: "attrs" type: "dict" default_value: "{}" }
+  param { name: "outputs" type: "dict or function" default_value: "None" deprecation { message: "Use output attributes or 'ctx.actions.declare_file' instead." } }
+  param { name: "executable" type: "bool" default_value: "unbound" }
+  param { name: "output_to_genfiles" type: "bool" default_value: "False" deprecation { message: "Output files are placed in the bin directory." } }
+  param { name: "fragments" type: "list of string" default_value: "[]" }
+  param { name: "host_fragments" type: "list of string" default_value: "[]" deprecation { message: "Host configuration fragments are not supported." } }
+  param { name: "_skylark_testable" type: "bool" default_value: "False" }
+  param { name: "toolchains" type: "list" default_value: "[]" }
+  param { name: "incompatible_use_toolchain_transition" type: "bool" default_value: "False" }
+  param { name: "doc" type: "string" default_value: "None" }
+  param { name: "provides" type: "list" default_value: "[]" }
+  param { name: "exec_compatible_with" type: "list of string" default_value: "[]" }
+  param { name: "analysis_test" type: "bool" default_value: "False" }
+  param { name: "build_setting" type: "BuildSetting" default_value: "None" }
+  param { name: "cfg" default_value: "None" }
+  param { name: "exec_groups" type: "dict" default_value: "None" }
+  param { name: "initializer" type: "function" default_value: "None" }
+  param { name: "parent" default_value: "None" }
+  param { name: "extendable" type: "bool or Label or string" default_value: "None" }
+  param { name: "subrules" type: "list of Subrule" default_value: "[]" }
+  return_type: "callable"
+}
+function {
+  name: "aspect"
+  param { name: "implementation" type: "function" required: true positional: true }
+  param { name: "attr_aspects" type: "list of string" default_value: "[]" }
+  param { name: "toolchains_aspects" type: "list" default_value: "[]" }
+  param { name: "attrs" type: "dict" default_value: "{}" }
+  param { name: "required_providers" type: "list" default_value: "[]" }
+  param { name: "required_aspect_providers" type: "list" default_value: "[]" }
+  param { name: "provides" type: "list" default_value: "[]" }
+  param { name: "requires" type: "list of Aspect" default_value: "[]" }
+  param { name: "fragments" type: "list of string" default_value: "[]" }
+  param { name: "host_fragments" type: "list of string" default_value: "[]" deprecation { message: "Host configuration fragments are not supported." } }
+  param { name: "toolchains" type: "list" default_value: "[]" }
+  param { name: "incompatible_use_toolchain_transition" type: "bool" default_value: "False" }
+  param { name: "doc" type: "string" default_value: "None" }
+  param { name: "apply_to_generating_rules" type: "bool" default_value: "False" }
+  param { name: "exec_compatible_with" type: "list of string" default_value: "[]" }
+  param { name: "exec_groups" type: "dict" default_value: "None" }
+  param { name: "subrules" type: "list of Subrule" default_value: "[]" }
+  return_type: "Aspect"
+}
+function {
+  name: "provider"
+  param { name: "doc" type: "string" positional: true default_value: "None" }
+  param { name: "fields" type: "list of string or dict" default_value: "None" }
+  param { name: "init" type: "function" default_value: "None" }
+  return_type: "Provider"
+}
+function {
+  name: "repository_rule"
+  param { name: "implementation" type: "function" required: true positional: true }
+  param { name: "attrs" type: "dict" default_value: "None" }
+  param { name: "local" type: "bool" default_value: "False" }
+  param { name: "environ" type: "list of string" default_value: "[]" deprecation { message: "Use 'repository_ctx.getenv' instead." } }
+  param { name: "configure" type: "bool" default_value: "False" }
+  param { name: "remotable" type: "bool" default_value: "False" }
+  param { name: "doc" type: "string" default_value: "None" }
+  return_type: "callable"
+}
+function {
+  name: "depset"
+  param { name: "direct" type: "list" positional: true default_value: "None" }
+  param { name: "order" type: "string" positional: true default_value: "\"default\"" }
+  param { name: "transitive" type: "list of depset" default_value: "None" }
+  param { name: "items" type: "list" default_value: "[]" deprecation { message: "Use 'direct' and 'transitive' instead." } }
+  return_type: "depset"
+}
+function {
+  name: "select"
+  param { name: "x" type: "dict" required: true positional: true }
+  param { name: "no_match_error" type: "string" positional: true default_value: "\"\"" }
+}
+function {
+  name: "glob"
+  param { name: "include" type: "list of string" positional: true default_value: "[]" }
+  param { name: "exclude" type: "list of string" positional: true default_value: "[]" }
+  param { name: "exclude_directories" type: "int" positional: true default_value: "1" }
+  param { name: "allow_empty" type: "bool" positional: true default_value: "unbound" }
+  return_type: "list of string"
+}
+function {
+  name: "native.glob"
+  param { name: "include" type: "list of string" positional: true default_value: "[]" }
+  param { name: "exclude" type: "list of string" positional: true default_value: "[]" }
+  param { name: "exclude_directories" type: "int" positional: true default_value: "1" }
+  param { name: "allow_empty" type: "bool" positional: true default_value: "unbound" }
+  return_type: "list of string"
+}
+function {
+  name: "struct"
+  kwargs: true
+  return_type: "struct"
+}
+function {
+  name: "Label"
+  param { name: "input" type: "string" required: true positional: true }
+  return_type: "Label"
+}
+function {
+  name: "all"
+  param { name: "x" type: "iterable" required: true positional: true }
+  return_type: "bool"
+}
+function {
+  name: "any"
+  param { name: "x" type: "iterable" required: true positional: true }
+  return_type: "bool"
+}
+function {
+  name: "bool"
+  param { name: "x" positional: true default_value: "False" }
+  return_type: "bool"
+}
+function {
+  name: "dict"
+  param { name: "pairs" positional: true default_value: "[]" }
+  kwargs: true
+  return_type: "dict"
+}
+function {
+  name: "dir"
+  param { name: "x" required: true positional: true }
+  return_type: "list of string"
+}
+function {
+  name: "enumerate"
+  param { name: "x" type: "iterable" required: true positional: true }
+  param { name: "start" type: "int" positional: true default_value: "0" }
+  return_type: "list of tuple"
+}
+function {
+  name: "fail"
+  param { name: "msg" positional: true default_value: "None" }
+  param { name: "attr" type: "string" positional: true default_value: "None" }
+  param { name: "sep" type: "string" default_value: "\" \"" }
+  varargs: true
+  return_type: "None"
+}
+function {
+  name: "float"
+  param { name: "x" positional: true default_value: "unbound" }
+  return_type: "float"
+}
+function {
+  name: "hasattr"
+  param { name: "x" required: true positional: true }
+  param { name: "name" type: "string" required: true positional: true }
+  return_type: "bool"
+}
+function {
+  name: "hash"
+  param { name: "value" type: "string" required: true positional: true }
+  return_type: "int"
+}
+function {
+  name: "int"
+  param { name: "x" required: true positional: true }
+  param { name: "base" type: "int" positional: true default_value: "unbound" }
+  return_type: "int"
+}
+function {
+  name: "len"
+  param { name: "x" required: true positional: true }
+  return_type: "int"
+}
+function {
+  name: "list"
+  param { name: "x" type: "iterable" positional: true default_value: "[]" }
+  return_type: "list"
+}
+function {
+  name: "module_name"
+  return_type: "string"
+}
+function {
+  name: "module_version"
+  return_type: "string"
+}
+function {
+  name: "package_name"
+  return_type: "string"
+}
+function {
+  name: "package_relative_label"
+  param { name: "input" type: "string or Label" required: true positional: true }
+  return_type: "Label"
+}
+function {
+  name: "print"
+  param { name: "sep" type: "string" default_value: "\" \"" }
+  varargs: true
+  return_type: "None"
+}
+function {
+  name: "repository_name"
+  return_type: "string"
+}
+function {
+  name: "repr"
+  param { name: "x" required: true positional: true }
+  return_type: "string"
+}
+function {
+  name: "reversed"
+  param { name: "sequence" type: "iterable" required: true positional: true }
+  return_type: "list"
+}
+function {
+  name: "sorted"
+  param { name: "iterable" type: "iterable" required: true positional: true }
+  param { name: "key" type: "function" default_value: "None" }
+  param { name: "reverse" type: "bool" default_value: "False" }
+  return_type: "list"
+}
+function {
+  name: "str"
+  param { name: "x" required: true positional: true }
+  return_type: "string"
+}
+function {
+  name: "tuple"
+  param { name: "x" type: "iterable" positional: true default_value: "()" }
+  return_type: "tuple"
+}
+function {
+  name: "type"
+  param { name: "x" required: true positional: true }
+  return_type: "string"
+}
+function {
+  name: "zip"
+  varargs: true
+  return_type: "list of tuple"
+}
+function {
+  name: "native.existing_rules"
+  return_type: "dict"
+}
+function {
+  name: "native.module_name"
+  return_type: "string"
+}
+function {
+  name: "native.module_version"
+  return_type: "string"
+}
+function {
+  name: "native.package_name"
+  return_type: "string"
+}
+function {
+  name: "native.package_relative_label"
+  param { name: "input" type: "string or Label" required: true positional: true }
+  return_type: "Label"
+}
+function {
+  name: "native.repository_name"
+  return_type: "string"
+}
+function {
+  name: "native.subpackages"
+  param { name: "include" type: "list of string" required: true }
+  param { name: "exclude" type: "list of string" default_value: "[]" }
+  param { name: "allow_empty" type: "bool" default_value: "False" }
+  return_type: "list of string"
+}
+function {
+  name: "json.encode"
+  param { name: "x" required: true positional: true }
+  return_type: "string"
+}
+function {
+  name: "json.encode_indent"
+  param { name: "x" required: true positional: true }
+  param { name: "prefix" type: "string" default_value: "\"\"" }
+  param { name: "indent" type: "string" default_value: "\"\\t\"" }
+  return_type: "string"
+}
+function {
+  name: "json.indent"
+  param { name: "s" type: "string" required: true positional: true }
+  param { name: "prefix" type: "string" default_value: "\"\"" }
+  param { name: "indent" type: "string" default_value: "\"\\t\"" }
+  return_type: "string"
+}
+function {
+  name: "attr.bool"
+  param { name: "default" type: "bool" default_value: "False" }
+  param { name: "doc" type: "string" default_value: "\"\"" }
+  param { name: "mandatory" type: "bool" default_value: "False" }
+  return_type: "Attribute"
+}
+function {
+  name: "attr.int"
+  param { name: "default" type: "int" default_value: "0" }
+  param { name: "doc" type: "string" default_value: "\"\"" }
+  param { name: "mandatory" type: "bool" default_value: "False" }
+  param { name: "values" type: "list of int" default_value: "[]" }
+  return_type: "Attribute"
+}
+function {
+  name: "attr.int_list"
+  param { name: "mandatory" type: "bool" default_value: "False" }
+  param { name: "allow_empty" type: "bool" default_value: "True" }
+  param { name: "non_empty" type: "bool" deprecation { message: "Use 'allow_empty = False' instead." } }
+  param { name: "default" type: "list of int" default_value: "[]" }
+  param { name: "doc" type: "string" default_value: "\"\"" }
+  return_type: "Attribute"
+}
+function {
+  name: "attr.string"
+  param { name: "default" type: "string" default_value: "\"\"" }
+  param { name: "doc" type: "string" default_value: "\"\"" }
+  param { name: "mandatory" type: "bool" default_value: "False" }
+  param { name: "values" type: "list of string" default_value: "[]" }
+  return_type: "Attribute"
+}
+function {
+  name: "attr.string_list"
+  param { name: "mandatory" type: "bool" default_value: "False" }
+  param { name: "allow_empty" type: "bool" default_value: "True" }
+  param { name: "non_empty" type: "bool" deprecation { message: "Use 'allow_empty = False' instead." } }
+  param { name: "default" type: "list of string" default_value: "[]" }
+  param { name: "doc" type: "string" default_value: "\"\"" }
+  return_type: "Attribute"
+}
+function {
+  name: "attr.string_dict"
+  param { name: "allow_empty" type: "bool" default_value: "True" }
+  param { name: "non_empty" type: "bool" deprecation { message: "Use 'allow_empty = False' instead." } }
+  param { name: "default" type: "dict" default_value: "{}" }
+  param { name: "doc" type: "string" default_value: "\"\"" }
+  param { name: "mandatory" type: "bool" default_value: "False" }
+  return_type: "Attribute"
+}
+function {
+  name: "attr.string_list_dict"
+  param { name: "allow_empty" type: "bool" default_value: "True" }
+  param { name: "non_empty" type: "bool" deprecation { message: "Use 'allow_empty = False' instead." } }
+  param { name: "default" type: "dict" default_value: "{}" }
+  param { name: "doc" type: "string" default_value: "\"\"" }
+  param { name: "mandatory" type: "bool" default_value: "False" }
+  return_type: "Attribute"
+}
+function {
+  name: "attr.label"
+  param { name: "default" type: "Label or string" default_value: "None" }
+  param { name: "doc" type: "string" default_value: "\"\"" }
+  param { name: "executable" type: "bool" default_value: "False" }
+  param { name: "allow_files" type: "bool or list of string" default_value: "None" }
+  param { name: "allow_single_file" type: "bool or list of string" default_value: "None" }
+  param { name: "mandatory" type: "bool" default_value: "False" }
+  param { name: "providers" type: "list" default_value: "[]" }
+  param { name: "allow_rules" type: "list of string" default_value: "None" }
+  param { name: "cfg" default_value: "None" }
+  param { name: "aspects" type: "list of Aspect" default_value: "[]" }
+  param { name: "flags" type: "list of string" default_value: "[]" }
+  param { name: "single_file" type: "bool" deprecation { message: "Use 'allow_single_file' instead." renamed_to: "allow_single_file" } }
+  return_type: "Attribute"
+}
+function {
+  name: "attr.label_list"
+  param { name: "allow_empty" type: "bool" default_value: "True" }
+  param { name: "default" type: "list of Label" default_value: "[]" }
+  param { name: "doc" type: "string" default_value: "\"\"" }
+  param { name: "allow_files" type: "bool or list of string" default_value: "None" }
+  param { name: "allow_rules" type: "list of string" default_value: "None" }
+  param { name: "providers" type: "list" default_value: "[]" }
+  param { name: "flags" type: "list of string" default_value: "[]" }
+  param { name: "mandatory" type: "bool" default_value: "False" }
+  param { name: "cfg" default_value: "None" }
+  param { name: "aspects" type: "list of Aspect" default_value: "[]" }
+  param { name: "non_empty" type: "bool" deprecation { message: "Use 'allow_empty = False' instead." } }
+  return_type: "Attribute"
+}
+function {
+  name: "attr.label_keyed_string_dict"
+  param { name: "allow_empty" type: "bool" default_value: "True" }
+  param { name: "default" type: "dict" default_value: "{}" }
+  param { name: "doc" type: "string" default_value: "\"\"" }
+  param { name: "allow_files" type: "bool or list of string" default_value: "None" }
+  param { name: "allow_rules" type: "list of string" default_value: "None" }
+  param { name: "providers" type: "list" default_value: "[]" }
+  param { name: "flags" type: "list of string" default_value: "[]" }
+  param { name: "mandatory" type: "bool" default_value: "False" }
+  param { name: "cfg" default_value: "None" }
+  param { name: "aspects" type: "list of Aspect" default_value: "[]" }
+  param { name: "non_empty" type: "bool" deprecation { message: "Use 'allow_empty = False' instead." } }
+  return_type: "Attribute"
+}
+function {
+  name: "attr.output"
+  param { name: "doc" type: "string" default_value: "\"\"" }
+  param { name: "mandatory" type: "bool" default_value: "False" }
+  return_type: "Attribute"
+}
+function {
+  name: "attr.output_list"
+  param { name: "allow_empty" type: "bool" default_value: "True" }
+  param { name: "doc" type: "string" default_value: "\"\"" }
+  param { name: "mandatory" type: "bool" default_value: "False" }
+  param { name: "non_empty" type: "bool" deprecation { message: "Use 'allow_empty = False' instead." } }
+  return_type: "Attribute"
+}
+function {
+  name: "ctx.actions.args"
+  return_type: "Args"
+}
+function {
+  name: "ctx.actions.declare_file"
+  param { name: "filename" type: "string" required: true positional: true }
+  param { name: "sibling" type: "File" default_value: "None" }
+  return_type: "File"
+}
+function {
+  name: "ctx.actions.declare_directory"
+  param { name: "filename" type: "string" required: true positional: true }
+  param { name: "sibling" type: "File" default_value: "None" }
+  return_type: "File"
+}
+function {
+  name: "ctx.actions.declare_symlink"
+  param { name: "filename" type: "string" required: true positional: true }
+  param { name: "sibling" type: "File" default_value: "None" }
+  return_type: "File"
+}
+function {
+  name: "ctx.actions.do_nothing"
+  param { name: "mnemonic" type: "string" required: true }
+  param { name: "inputs" type: "list or depset of File" default_value: "[]" }
+  return_type: "None"
+}
+function {
+  name: "ctx.actions.expand_template"
+  param { name: "template" type: "File" required: true }
+  param { name: "output" type: "File" required: true }
+  param { name: "substitutions" type: "dict" default_value: "{}" }
+  param { name: "is_executable" type: "bool" default_value: "False" }
+  param { name: "computed_substitutions" type: "TemplateDict" default_value: "unbound" }
+  return_type: "None"
+}
+function {
+  name: "ctx.actions.run"
+  param { name: "outputs" type: "list of File" required: true }
+  param { name: "inputs" type: "list or depset of File" default_value: "[]" }
+  param { name: "unused_inputs_list" type: "File" default_value: "None" }
+  param { name: "executable" type: "File or string" required: true }
+  param { name: "tools" type: "list or depset" default_value: "unbound" }
+  param { name: "arguments" type: "list" default_value: "[]" }
+  param { name: "mnemonic" type: "string" default_value: "None" }
+  param { name: "progress_message" type: "string" default_value: "None" }
+  param { name: "use_default_shell_env" type: "bool" default_value: "False" }
+  param { name: "env" type: "dict" default_value: "None" }
+  param { name: "execution_requirements" type: "dict" default_value: "None" }
+  param { name: "input_manifests" type: "list" default_value: "None" }
+  param { name: "exec_group" type: "string" default_value: "None" }
+  param { name: "shadowed_action" type: "Action" default_value: "None" }
+  param { name: "resource_set" type: "function" default_value: "None" }
+  param { name: "toolchain" type: "Label or string" default_value: "unbound" }
+  return_type: "None"
+}
+function {
+  name: "ctx.actions.run_shell"
+  param { name: "outputs" type: "list of File" required: true }
+  param { name: "inputs" type: "list or depset of File" default_value: "[]" }
+  param { name: "tools" type: "list or depset" default_value: "unbound" }
+  param { name: "arguments" type: "list" default_value: "[]" }
+  param { name: "mnemonic" type: "string" default_value: "None" }
+  param { name: "command" type: "string or list of string" required: true }
+  param { name: "progress_message" type: "string" default_value: "None" }
+  param { name: "use_default_shell_env" type: "bool" default_value: "False" }
+  param { name: "env" type: "dict" default_value: "None" }
+  param { name: "execution_requirements" type: "dict" default_value: "None" }
+  param { name: "input_manifests" type: "list" default_value: "None" }
+  param { name: "exec_group" type: "string" default_value: "None" }
+  param { name: "shadowed_action" type: "Action" default_value: "None" }
+  param { name: "resource_set" type: "function" default_value: "None" }
+  param { name: "toolchain" type: "Label or string" default_value: "unbound" }
+  return_type: "None"
+}
+function {
+  name: "ctx.actions.symlink"
+  param { name: "output" type: "File" required: true }
+  param { name: "target_file" type: "File" default_value: "None" }
+  param { name: "target_path" type: "string" default_value: "None" }
+  param { name: "is_executable" type: "bool" default_value: "False" }
+  param { name: "progress_message" type: "string" default_value: "None" }
+  return_type: "None"
+}
+function {
+  name: "ctx.actions.write"
+  param { name: "output" type: "File" required: true positional: true }
+  param { name: "content" type: "string or Args" required: true positional: true }
+  param { name: "is_executable" type: "bool" positional: true default_value: "False" }
+  return_type: "None"
+}
+function {
+  name: "ctx.actions.args.add"
+  param { name: "arg_name_or_value" required: true positional: true }
+  param { name: "value" positional: true default_value: "unbound" }
+  param { name: "format" type: "string" default_value: "None" }
+  return_type: "Args"
+}
+function {
+  name: "ctx.actions.args.add_all"
+  param { name: "arg_name_or_values" required: true positional: true }
+  param { name: "values" positional: true default_value: "unbound" }
+  param { name: "map_each" type: "function" default_value: "None" }
+  param { name: "format_each" type: "string" default_value: "None" }
+  param { name: "before_each" type: "string" default_value: "None" }
+  param { name: "omit_if_empty" type: "bool" default_value: "True" }
+  param { name: "uniquify" type: "bool" default_value: "False" }
+  param { name: "expand_directories" type: "bool" default_value: "True" }
+  param { name: "terminate_with" type: "string" default_value: "None" }
+  param { name: "allow_closure" type: "bool" default_value: "False" }
+  return_type: "Args"
+}
+function {
+  name: "ctx.actions.args.add_joined"
+  param { name: "arg_name_or_values" required: true positional: true }
+  param { name: "values" positional: true default_value: "unbound" }
+  param { name: "join_with" type: "string" required: true }
+  param { name: "map_each" type: "function" default_value: "None" }
+  param { name: "format_each" type: "string" default_value: "None" }
+  param { name: "format_joined" type: "string" default_value: "None" }
+  param { name: "omit_if_empty" type: "bool" default_value: "True" }
+  param { name: "uniquify" type: "bool" default_value: "False" }
+  param { name: "expand_directories" type: "bool" default_value: "True" }
+  param { name: "allow_closure" type: "bool" default_value: "False" }
+  return_type: "Args"
+}
+function {
+  name: "ctx.actions.args.use_param_file"
+  param { name: "param_file_arg" type: "string" required: true positional: true }
+  param { name: "use_always" type: "bool" default_value: "False" }
+  return_type: "Args"
+}
+function {
+  name: "ctx.actions.args.set_param_file_format"
+  param { name: "format" type: "string" required: true positional: true }
+  return_type: "Args"
+}
+function {
+  name: "ctx.new_file"
+  varargs: true
+  return_type: "File"
+  deprecation { message: "Use 'ctx.actions.declare_file' instead." }
+}
+function {
+  name: "ctx.expand_location"
+  param { name: "input" type: "string" required: true positional: true }
+  param { name: "targets" type: "list of Target" positional: true default_value: "[]" }
+  return_type: "string"
+}
+function {
+  name: "ctx.expand_make_variables"
+  param { name: "attribute_name" type: "string" required: true positional: true }
+  param { name: "command" type: "string" required: true positional: true }
+  param { name: "additional_substitutions" type: "dict" required: true positional: true }
+  return_type: "string"
+}
+function {
+  name: "string.capitalize"
+  return_type: "string"
+}
+function {
+  name: "string.count"
+  param { name: "sub" type: "string" required: true positional: true }
+  param { name: "start" type: "int" positional: true default_value: "None" }
+  param { name: "end" type: "int" positional: true default_value: "None" }
+  return_type: "int"
+}
+function {
+  name: "string.elems"
+  return_type: "list of string"
+}
+function {
+  name: "string.endswith"
+  param { name: "sub" type: "string or tuple of strings" required: true positional: true }
+  param { name: "start" type: "int" positional: true default_value: "None" }
+  param { name: "end" type: "int" positional: true default_value: "None" }
+  return_type: "bool"
+}
+function {
+  name: "string.find"
+  param { name: "sub" type: "string" required: true positional: true }
+  param { name: "start" type: "int" positional: true default_value: "None" }
+  param { name: "end" type: "int" positional: true default_value: "None" }
+  return_type: "int"
+}
+function {
+  name: "string.format"
+  varargs: true
+  kwargs: true
+  return_type: "string"
+}
+function {
+  name: "string.index"
+  param { name: "sub" type: "string" required: true positional: true }
+  param { name: "start" type: "int" positional: true default_value: "None" }
+  param { name: "end" type: "int" positional: true default_value: "None" }
+  return_type: "int"
+}
+function {
+  name: "string.isalnum"
+  return_type: "bool"
+}
+function {
+  name: "string.isalpha"
+  return_type: "bool"
+}
+function {
+  name: "string.isdigit"
+  return_type: "bool"
+}
+function {
+  name: "string.islower"
+  return_type: "bool"
+}
+function {
+  name: "string.isspace"
+  return_type: "bool"
+}
+function {
+  name: "string.istitle"
+  return_type: "bool"
+}
+function {
+  name: "string.isupper"
+  return_type: "bool"
+}
+function {
+  name: "string.join"
+  param { name: "elements" type: "iterable of strings" required: true positional: true }
+  return_type: "string"
+}
+function {
+  name: "string.lower"
+  return_type: "string"
+}
+function {
+  name: "string.lstrip"
+  param { name: "chars" type: "string" positional: true default_value: "None" }
+  return_type: "string"
+}
+function {
+  name: "string.partition"
+  param { name: "sep" type: "string" required: true positional: true }
+  return_type: "tuple"
+}
+function {
+  name: "string.removeprefix"
+  param { name: "prefix" type: "string" required: true positional: true }
+  return_type: "string"
+}
+function {
+  name: "string.removesuffix"
+  param { name: "suffix" type: "string" required: true positional: true }
+  return_type: "string"
+}
+function {
+  name: "string.replace"
+  param { name: "old" type: "string" required: true positional: true }
+  param { name: "new" type: "string" required: true positional: true }
+  param { name: "count" type: "int" positional: true default_value: "-1" }
+  return_type: "string"
+}
+function {
+  name: "string.rfind"
+  param { name: "sub" type: "string" required: true positional: true }
+  param { name: "start" type: "int" positional: true default_value: "None" }
+  param { name: "end" type: "int" positional: true default_value: "None" }
+  return_type: "int"
+}
+function {
+  name: "string.rindex"
+  param { name: "sub" type: "string" required: true positional: true }
+  param { name: "start" type: "int" positional: true default_value: "None" }
+  param { name: "end" type: "int" positional: true default_value: "None" }
+  return_type: "int"
+}
+function {
+  name: "string.rpartition"
+  param { name: "sep" type: "string" required: true positional: true }
+  return_type: "tuple"
+}
+function {
+  name: "string.rsplit"
+  param { name: "sep" type: "string" positional: true default_value: "None" }
+  param { name: "maxsplit" type: "int" positional: true default_value: "None" }
+  return_type: "list of string"
+}
+function {
+  name: "string.rstrip"
+  param { name: "chars" type: "string" positional: true default_value: "None" }
+  return_type: "string"
+}
+function {
+  name: "string.split"
+  param { name: "sep" type: "string" positional: true default_value: "None" }
+  param { name: "maxsplit" type: "int" positional: true default_value: "None" }
+  return_type: "list of string"
+}
+function {
+  name: "string.splitlines"
+  param { name: "keepends" type: "bool" positional: true default_value: "False" }
+  return_type: "list of string"
+}
+function {
+  name: "string.startswith"
+  param { name: "sub" type: "string or tuple of strings" required: true positional: true }
+  param { name: "start" type: "int" positional: true default_value: "None" }
+  param { name: "end" type: "int" positional: true default_value: "None" }
+  return_type: "bool"
+}
+function {
+  name: "string.strip"
+  param { name: "chars" type: "string" positional: true default_value: "None" }
+  return_type: "string"
+}
+function {
+  name: "string.title"
+  return_type: "string"
+}
+function {
+  name: "string.upper"
+  return_type: "string"
+}
+function {
+  name: "dict.clear"
+  return_type: "None"
+}
+function {
+  name: "dict.items"
+  return_type: "list of tuple"
+}
+function {
+  name: "dict.keys"
+  return_type: "list"
+}
+function {
+  name: "dict.popitem"
+  return_type: "tuple"
+}
+function {
+  name: "dict.update"
+  param { name: "pairs" positional: true default_value: "[]" }
+  kwargs: true
+  return_type: "None"
+}
+function {
+  name: "dict.values"
+  return_type: "list"
+}
+function {
+  name: "list.append"
+  param { name: "item" required: true positional: true }
+  return_type: "None"
+}
+function {
+  name: "list.clear"
+  return_type: "None"
+}
+function {
+  name: "list.extend"
+  param { name: "items" type: "iterable" required: true positional: true }
+  return_type: "None"
+}
+function {
+  name: "list.index"
+  param { name: "x" required: true positional: true }
+  param { name: "start" type: "int" positional: true default_value: "None" }
+  param { name: "end" type: "int" positional: true default_value: "None" }
+  return_type: "int"
+}
+function {
+  name: "list.insert"
+  param { name: "index" type: "int" required: true positional: true }
+  param { name: "item" required: true positional: true }
+  return_type: "None"
+}
+function {
+  name: "list.remove"
+  param { name: "x" required: true positional: true }
+  return_type: "None"
+}
+function {
+  name: "depset.to_list"
+  return_type: "list"
+}
+function {
+  name: "Label.relative"
+  param { name: "relName" type: "string" required: true positional: true }
+  return_type: "Label"
+  deprecation { message: "Use 'Label.same_package_label' or 'native.package_relative_label' instead." }
+}
+function {
+  name: "Label.same_package_label"
+  param { name: "target_name" type: "string" required: true positional: true }
+  return_type: "Label"
+}
+`
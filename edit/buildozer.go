@@ -37,6 +37,7 @@ import (
 	"github.com/bazelbuild/buildtools/build"
 	"github.com/bazelbuild/buildtools/file"
 	"github.com/bazelbuild/buildtools/labels"
+	"github.com/bazelbuild/buildtools/lang"
 	"github.com/bazelbuild/buildtools/wspace"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
@@ -44,21 +45,32 @@ import (
 
 // Options represents choices about how buildozer should behave.
 type Options struct {
-	Stdout            bool      // write changed BUILD file to stdout
-	Buildifier        string    // path to buildifier binary
-	Parallelism       int       // number of cores to use for concurrent actions
-	NumIO             int       // number of concurrent actions
-	CommandsFiles     []string  // file names to read commands from, use '-' for stdin (format:|-separated command line arguments to buildozer, excluding flags
-	KeepGoing         bool      // apply all commands, even if there are failures
-	FilterRuleTypes   []string  // list of rule types to change, empty means all
-	PreferEOLComments bool      // when adding a new comment, put it on the same line if possible
-	RootDir           string    // If present, use this folder rather than $PWD to find the root dir
-	Quiet             bool      // suppress informational messages.
-	EditVariables     bool      // for attributes that simply assign a variable (e.g. hdrs = LIB_HDRS), edit the build variable instead of appending to the attribute.
-	IsPrintingProto   bool      // output serialized devtools.buildozer.Output protos instead of human-readable strings
-	IsPrintingJSON    bool      // output serialized devtools.buildozer.Output json instead of human-readable strings
-	OutWriter         io.Writer // where to write normal output (`os.Stdout` will be used if not specified)
-	ErrWriter         io.Writer // where to write error output (`os.Stderr` will be used if not specified)
+	Stdout            bool                // write changed BUILD file to stdout
+	Buildifier        string              // path to buildifier binary
+	Parallelism       int                 // number of cores to use for concurrent actions
+	NumIO             int                 // number of concurrent actions
+	CommandsFiles     []string            // file names to read commands from, use '-' for stdin (format:|-separated command line arguments to buildozer, excluding flags
+	KeepGoing         bool                // apply all commands, even if there are failures
+	FilterRuleTypes   []string            // list of rule types to change, empty means all
+	PreferEOLComments bool                // when adding a new comment, put it on the same line if possible
+	RootDir           string              // If present, use this folder rather than $PWD to find the root dir
+	Quiet             bool                // suppress informational messages.
+	EditVariables     bool                // for attributes that simply assign a variable (e.g. hdrs = LIB_HDRS), edit the build variable instead of appending to the attribute.
+	IsPrintingProto   bool                // output serialized devtools.buildozer.Output protos instead of human-readable strings
+	IsPrintingJSON    bool                // output serialized devtools.buildozer.Output json instead of human-readable strings
+	OutWriter         io.Writer           // where to write normal output (`os.Stdout` will be used if not specified)
+	ErrWriter         io.Writer           // where to write error output (`os.Stderr` will be used if not specified)
+	MacroSuffixes     map[string][]string // maps macro kinds to the suffixes of the target names they generate, e.g. my_macro -> [_lib, _test]
+
+	// RuleSchemas returns the attribute schemas of Starlark rules and macros available in a BUILD
+	// file. If set, they're used to determine the types of attributes of rules of these kinds.
+	RuleSchemas func(f *build.File) map[string]map[string]lang.AttributeSchema
+
+	// RepoMapping returns the repository mapping of the main repository of the workspace a file
+	// belongs to. If set, labels passed as arguments are compared with and added to attributes
+	// using apparent repository names, e.g. "@@rules_go+//go:def" is treated as "@rules_go//go:def"
+	// and "@my_module//foo:bar" as "//foo:bar" in the module "my_module".
+	RepoMapping func(f *build.File) *labels.RepoMapping
 }
 
 // NewOpts returns a new Options struct with some defaults set.
@@ -73,12 +85,14 @@ const stdinPackageName = "-" // the special package name to represent stdin
 
 // CmdEnvironment stores the information the commands below have access to.
 type CmdEnvironment struct {
-	File   *build.File                  // the AST
-	Rule   *build.Rule                  // the rule to modify
-	Vars   map[string]*build.AssignExpr // global variables set in the build file
-	Pkg    string                       // the full package name
-	Args   []string                     // the command-line arguments
-	output *apipb.Output_Record         // output proto, stores whatever a command wants to print
+	File        *build.File                  // the AST
+	Rule        *build.Rule                  // the rule to modify
+	Vars        map[string]*build.AssignExpr // global variables set in the build file
+	Pkg         string                       // the full package name
+	Args        []string                     // the command-line arguments
+	Schemas     RuleSchemas                  // attribute schemas of Starlark rules and macros available in the file
+	RepoMapping *labels.RepoMapping          // repository mapping of the main repository, nil if unknown
+	output      *apipb.Output_Record         // output proto, stores whatever a command wants to print
 }
 
 // The cmdXXX functions implement the various commands.
@@ -86,11 +100,11 @@ type CmdEnvironment struct {
 func cmdAdd(opts *Options, env CmdEnvironment) (*build.File, error) {
 	attr := env.Args[0]
 	for _, val := range env.Args[1:] {
-		if IsIntList(attr) {
+		if env.Schemas.IsIntList(env.Rule.Kind(), attr) {
 			AddValueToListAttribute(env.Rule, attr, env.Pkg, &build.LiteralExpr{Token: val}, &env.Vars)
 			continue
 		}
-		strVal := getStringExpr(val, env.Pkg)
+		strVal := getStringExpr(val, env)
 		AddValueToListAttribute(env.Rule, attr, env.Pkg, strVal, &env.Vars)
 	}
 	ResolveAttr(env.Rule, attr, env.Pkg)
@@ -210,7 +224,7 @@ func cmdMove(opts *Options, env CmdEnvironment) (*build.File, error) {
 	}
 	fixed := false
 	for _, val := range env.Args[2:] {
-		if deleted := ListAttributeDelete(env.Rule, oldAttr, val, env.Pkg); deleted != nil {
+		if deleted := ListAttributeDelete(env.Rule, oldAttr, mappedLabel(val, env), env.Pkg); deleted != nil {
 			AddValueToListAttribute(env.Rule, newAttr, env.Pkg, deleted, &env.Vars)
 			fixed = true
 		}
@@ -386,7 +400,7 @@ func cmdRemove(opts *Options, env CmdEnvironment) (*build.File, error) {
 		fixed := false
 		for _, key := range attrKeysForPattern(env.Rule, env.Args[0]) {
 			for _, val := range env.Args[1:] {
-				ListAttributeDelete(env.Rule, key, val, env.Pkg)
+				ListAttributeDelete(env.Rule, key, mappedLabel(val, env), env.Pkg)
 				fixed = true
 			}
 			ResolveAttr(env.Rule, key, env.Pkg)
@@ -444,12 +458,12 @@ func cmdRename(opts *Options, env CmdEnvironment) (*build.File, error) {
 }
 
 func cmdReplace(opts *Options, env CmdEnvironment) (*build.File, error) {
-	oldV := getStringValue(env.Args[1])
-	newV := getStringValue(env.Args[2])
+	oldV := mappedLabel(env.Args[1], env)
+	newV := mappedLabel(env.Args[2], env)
 	for _, key := range attrKeysForPattern(env.Rule, env.Args[0]) {
 		attr := env.Rule.Attr(key)
 		if e, ok := attr.(*build.StringExpr); ok {
-			if labels.Equal(e.Value, oldV, env.Pkg) {
+			if env.RepoMapping.Equal(e.Value, oldV, env.Pkg) {
 				env.Rule.SetAttr(key, getAttrValueExpr(key, []string{newV}, env))
 			}
 		} else {
@@ -503,26 +517,50 @@ func cmdSetIfAbsent(opts *Options, env CmdEnvironment) (*build.File, error) {
 }
 
 func getAttrValueExpr(attr string, args []string, env CmdEnvironment) build.Expr {
+	if env.File != nil && env.File.Type == build.TypeModule {
+		return getModuleAttrValueExpr(attr, args)
+	}
 	switch {
 	case attr == "kind":
 		return nil
-	case IsIntList(attr):
+	case env.Schemas.IsIntList(env.Rule.Kind(), attr):
 		var list []build.Expr
 		for _, i := range args {
 			list = append(list, &build.LiteralExpr{Token: i})
 		}
 		return &build.ListExpr{List: list}
-	case IsList(attr) && !(len(args) == 1 && strings.HasPrefix(args[0], "glob(")):
+	case env.Schemas.IsList(env.Rule.Kind(), attr) && !(len(args) == 1 && strings.HasPrefix(args[0], "glob(")):
 		var list []build.Expr
 		for _, arg := range args {
-			list = append(list, getStringExpr(arg, env.Pkg))
+			list = append(list, getStringExpr(arg, env))
 		}
 		return &build.ListExpr{List: list}
 	case len(args) == 0:
 		// Expected a non-list argument, nothing provided
 		return &build.Ident{Name: "None"}
-	case IsString(attr):
-		return getStringExpr(args[0], env.Pkg)
+	case env.Schemas.IsString(env.Rule.Kind(), attr):
+		return getStringExpr(args[0], env)
+	default:
+		return &build.Ident{Name: args[0]}
+	}
+}
+
+// getModuleAttrValueExpr is the equivalent of getAttrValueExpr for arguments of MODULE.bazel
+// functions. Labels are never shortened because MODULE.bazel files don't belong to a package.
+func getModuleAttrValueExpr(attr string, args []string) build.Expr {
+	switch {
+	case attr == "kind":
+		return nil
+	case IsModuleList(attr):
+		var list []build.Expr
+		for _, arg := range args {
+			list = append(list, &build.StringExpr{Value: getStringValue(arg)})
+		}
+		return &build.ListExpr{List: list}
+	case len(args) == 0:
+		return &build.Ident{Name: "None"}
+	case IsModuleString(attr):
+		return &build.StringExpr{Value: getStringValue(args[0])}
 	default:
 		return &build.Ident{Name: args[0]}
 	}
@@ -536,13 +574,30 @@ func getStringValue(value string) string {
 	return value
 }
 
+// mappedLabel unquotes an input argument and, if it's a label, converts it to the form used in
+// BUILD files using the repository mapping (see labels.RepoMapping.Unresolve), so that it can be
+// compared with the values of attributes.
+func mappedLabel(value string, env CmdEnvironment) string {
+	value = getStringValue(value)
+	if env.RepoMapping == nil || !strings.HasPrefix(value, "@") {
+		return value
+	}
+	return env.RepoMapping.Unresolve(labels.Parse(value)).Format()
+}
+
 // getStringExpr creates a StringExpr from an input argument, which can be either quoted or not,
-// and shortens the label value if possible.
-func getStringExpr(value, pkg string) build.Expr {
+// and shortens the label value if possible (see labels.RepoMapping.Shorten).
+func getStringExpr(value string, env CmdEnvironment) build.Expr {
+	shorten := func(label string) string {
+		if !ShortenLabelsFlag {
+			return label
+		}
+		return env.RepoMapping.Shorten(label, env.Pkg)
+	}
 	if unquoted, triple, err := build.Unquote(value); err == nil {
-		return &build.StringExpr{Value: ShortenLabel(unquoted, pkg), TripleQuote: triple}
+		return &build.StringExpr{Value: shorten(unquoted), TripleQuote: triple}
 	}
-	return &build.StringExpr{Value: ShortenLabel(value, pkg)}
+	return &build.StringExpr{Value: shorten(value)}
 }
 
 func cmdCopy(opts *Options, env CmdEnvironment) (*build.File, error) {
@@ -579,7 +634,7 @@ func cmdDictAdd(opts *Options, env CmdEnvironment) (*build.File, error) {
 		if len(kv) != 2 {
 			return nil, fmt.Errorf("no colon in dict_add argument %q found", x)
 		}
-		expr := getStringExpr(kv[1], env.Pkg)
+		expr := getStringExpr(kv[1], env)
 
 		prev := DictionaryGet(dict, kv[0])
 		if prev == nil {
@@ -607,7 +662,7 @@ func cmdDictSet(opts *Options, env CmdEnvironment) (*build.File, error) {
 		if len(kv) != 2 {
 			return nil, fmt.Errorf("no colon in dict_set argument %q found", x)
 		}
-		expr := getStringExpr(kv[1], env.Pkg)
+		expr := getStringExpr(kv[1], env)
 		// Set overwrites previous values.
 		DictionarySet(dict, kv[0], expr)
 	}
@@ -657,7 +712,7 @@ func cmdDictListAdd(opts *Options, env CmdEnvironment) (*build.File, error) {
 	}
 
 	for _, val := range args {
-		expr := getStringExpr(val, env.Pkg)
+		expr := getStringExpr(val, env)
 		prev = AddValueToList(prev, env.Pkg, expr, true)
 	}
 
@@ -738,11 +793,13 @@ var readonlyCommands = map[string]bool{
 	"print_comment": true,
 }
 
-func expandTargets(f *build.File, rule string) ([]*build.Rule, error) {
+func expandTargets(opts *Options, f *build.File, rule string) ([]*build.Rule, error) {
 	if r := FindRuleByName(f, rule); r != nil {
 		return []*build.Rule{r}, nil
 	} else if r := FindExportedFile(f, rule); r != nil {
 		return []*build.Rule{r}, nil
+	} else if r := FindGeneratingRule(f, rule, opts.MacroSuffixes); r != nil {
+		return []*build.Rule{r}, nil
 	} else if rule == "all" || rule == "*" {
 		// "all" is a valid name, it is a wildcard only if no such rule is found.
 		return f.Rules(""), nil
@@ -893,6 +950,38 @@ func getGlobalVariables(exprs []build.Expr) (vars map[string]*build.AssignExpr)
 // in scripts are free to do so.
 var BuildFileNames = [...]string{"BUILD.bazel", "BUILD", "BUCK"}
 
+// getParser returns the parser for the file `name`. Stdin and files named after one of
+// BuildFileNames are parsed as BUILD files, other files (e.g. WORKSPACE, MODULE.bazel or
+// .bzl files) are parsed according to their names.
+func getParser(name string) func(filename string, data []byte) (*build.File, error) {
+	if name == stdinPackageName {
+		return build.ParseBuild
+	}
+	base := filepath.Base(name)
+	for _, buildFileName := range BuildFileNames {
+		if base == buildFileName {
+			return build.ParseBuild
+		}
+	}
+	return build.Parse
+}
+
+// getParserForType returns the parser that produces files of the given type.
+func getParserForType(fileType build.FileType) func(filename string, data []byte) (*build.File, error) {
+	switch fileType {
+	case build.TypeWorkspace:
+		return build.ParseWorkspace
+	case build.TypeModule:
+		return build.ParseModule
+	case build.TypeBzl:
+		return build.ParseBzl
+	case build.TypeDefault:
+		return build.ParseDefault
+	default:
+		return build.ParseBuild
+	}
+}
+
 // Buildifier formats the build file using the buildifier logic.
 type Buildifier interface {
 	// Buildify formats the build file and returns the formatted contents.
@@ -954,11 +1043,19 @@ func rewrite(opts *Options, commandsForFile commandsForFile) *rewriteResult {
 		}
 	}
 
-	f, err := build.ParseBuild(name, data)
+	f, err := getParser(name)(name, data)
 	if err != nil {
 		return &rewriteResult{file: name, errs: []error{err}}
 	}
 	f.WorkspaceRoot, f.Pkg, f.Label = wspace.SplitFilePath(name)
+	var schemas RuleSchemas
+	if opts.RuleSchemas != nil {
+		schemas = opts.RuleSchemas(f)
+	}
+	var mapping *labels.RepoMapping
+	if opts.RepoMapping != nil {
+		mapping = opts.RepoMapping(f)
+	}
 
 	vars := map[string]*build.AssignExpr{}
 	if opts.EditVariables {
@@ -979,7 +1076,7 @@ func rewrite(opts *Options, commandsForFile commandsForFile) *rewriteResult {
 			absPkg = f.Pkg
 		}
 
-		targets, err := expandTargets(f, rule)
+		targets, err := expandTargets(opts, f, rule)
 		if err != nil {
 			cerr := commandError(commands, target, err)
 			errs = append(errs, cerr)
@@ -998,7 +1095,7 @@ func rewrite(opts *Options, commandsForFile commandsForFile) *rewriteResult {
 			}
 			for _, r := range cmdTargets {
 				record := &apipb.Output_Record{}
-				newf, err := cmdInfo.Fn(opts, CmdEnvironment{f, r, vars, absPkg, cmd.tokens[1:], record})
+				newf, err := cmdInfo.Fn(opts, CmdEnvironment{f, r, vars, absPkg, cmd.tokens[1:], schemas, mapping, record})
 				if len(record.Fields) != 0 {
 					records = append(records, record)
 				}
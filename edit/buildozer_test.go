@@ -25,6 +25,9 @@ import (
 	"testing"
 
 	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/labels"
+
+	buildpb "github.com/bazelbuild/buildtools/build_proto"
 )
 
 var removeCommentTests = []struct {
@@ -439,3 +442,265 @@ func TestCmdDictAddSet_missingColon(t *testing.T) {
 		})
 	}
 }
+
+var expandGeneratedTargetsTests = []struct {
+	target       string
+	expectedLine int // line of the rule the target resolves to, 0 if it's not found
+}{
+	{"lib", 1},
+	{"x_lib", 5},
+	{"x_test", 5},
+	{"x_bin", 0},
+	{"a_test", 7},
+	{"b_test", 7},
+	{"c_test", 0},
+	{"a_bin", 8},
+	{"b_bin", 8},
+	{"b_bin_suffix", 0},
+}
+
+func TestExpandGeneratedTargets(t *testing.T) {
+	bld, err := build.Parse("BUILD", []byte(`cc_library(
+    name = "lib",
+)
+
+my_macro(name = "x")
+
+[cc_test(name = n + "_test") for n in ["a", "b"]]
+[cc_binary(name = "%s_bin" % n, srcs = [n + ".cc"]) for n in ["a", "b"]]
+[cc_binary(name = n) for n in VARIABLE]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := NewOpts()
+	opts.MacroSuffixes = map[string][]string{"my_macro": {"_lib", "_test"}}
+	for _, tt := range expandGeneratedTargetsTests {
+		rules, err := expandTargets(opts, bld, tt.target)
+		if tt.expectedLine == 0 {
+			if err == nil {
+				t.Errorf("expandTargets(%q) = %v, want error", tt.target, rules)
+			}
+			continue
+		}
+		if err != nil || len(rules) != 1 {
+			t.Errorf("expandTargets(%q) = %v, %v; want a single rule", tt.target, rules, err)
+			continue
+		}
+		if start, _ := rules[0].Call.Span(); start.Line != tt.expectedLine {
+			t.Errorf("expandTargets(%q) returned a rule on line %d, want %d", tt.target, start.Line, tt.expectedLine)
+		}
+	}
+}
+
+func TestParseMacroSuffixes(t *testing.T) {
+	got, err := ParseMacroSuffixes([]string{"my_macro=_lib", "my_macro=_test", "other="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"my_macro": {"_lib", "_test"},
+		"other":    {""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseMacroSuffixes() = %v, want %v", got, want)
+	}
+	if _, err := ParseMacroSuffixes([]string{"_lib"}); err == nil {
+		t.Errorf("ParseMacroSuffixes(%q) succeeded, want error", "_lib")
+	}
+}
+
+var nonBuildFileTests = []struct {
+	file     string
+	contents string
+	args     []string
+	expected string
+}{
+	{
+		"MODULE.bazel",
+		`module(name = "foo")
+
+bazel_dep(name = "rules_go", version = "0.1")
+bazel_dep(name = "rules_cc", version = "0.2")
+`,
+		[]string{"set version 0.3", "//:MODULE.bazel:rules_go"},
+		`module(name = "foo")
+
+bazel_dep(name = "rules_go", version = "0.3")
+bazel_dep(name = "rules_cc", version = "0.2")
+`,
+	},
+	{
+		"MODULE.bazel",
+		`bazel_dep(name = "rules_go", version = "0.1")
+bazel_dep(name = "rules_cc", version = "0.2")
+`,
+		[]string{"set dev_dependency True", "//:MODULE.bazel:%bazel_dep"},
+		`bazel_dep(name = "rules_go", dev_dependency = True, version = "0.1")
+bazel_dep(name = "rules_cc", dev_dependency = True, version = "0.2")
+`,
+	},
+	{
+		"a/defs.bzl",
+		`foo_library(name = "foo")
+`,
+		[]string{"add deps :bar", "//a:defs.bzl:foo"},
+		`foo_library(name = "foo", deps = [":bar"])
+`,
+	},
+}
+
+func TestEditNonBuildFiles(t *testing.T) {
+	for i, tt := range nonBuildFileTests {
+		tmp, err := ioutil.TempDir("", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(tmp)
+		if err := os.MkdirAll(filepath.Join(tmp, "a"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(tmp, "WORKSPACE"), nil, 0755); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(tmp, filepath.FromSlash(tt.file))
+		if err := ioutil.WriteFile(path, []byte(tt.contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		opts := NewOpts()
+		opts.RootDir = tmp
+		opts.Quiet = true
+		if ret := Buildozer(opts, tt.args); ret != 0 {
+			t.Errorf("%d: Buildozer(%q) = %d, want 0", i, tt.args, ret)
+			continue
+		}
+		got, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.expected {
+			t.Errorf("%d: Buildozer(%q):\ngot:\n%s\nexpected:\n%s", i, tt.args, got, tt.expected)
+		}
+	}
+}
+
+func TestRuleSchemas(t *testing.T) {
+	schemas := RuleSchemas{
+		"my_rule": {
+			"srcs":      {Type: buildpb.Attribute_LABEL},
+			"my_inputs": {Type: buildpb.Attribute_LABEL_LIST},
+			"deps":      {Type: buildpb.Attribute_UNKNOWN},
+		},
+	}
+
+	bld, err := build.Parse("BUILD", []byte(`my_rule(name = "a")
+cc_library(name = "b")`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rule := range bld.Rules("") {
+		for _, args := range [][]string{{"srcs", "a.cc"}, {"my_inputs", "c"}, {"deps", "d"}} {
+			env := CmdEnvironment{File: bld, Rule: rule, Pkg: "pkg", Args: args, Schemas: schemas}
+			if _, err := cmdSet(NewOpts(), env); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	expected := `my_rule(
+    name = "a",
+    srcs = "a.cc",
+    my_inputs = ["c"],
+    deps = ["d"],
+)
+
+cc_library(
+    name = "b",
+    srcs = ["a.cc"],
+    my_inputs = c,
+    deps = ["d"],
+)`
+	if got := strings.TrimSpace(string(build.Format(bld))); got != expected {
+		t.Errorf("cmdSet() with rule schemas:\ngot:\n%s\nexpected:\n%s", got, expected)
+	}
+
+	if !schemas.ContainsLabels("my_rule", "my_inputs") || schemas.ContainsLabels("cc_library", "my_inputs") {
+		t.Errorf("ContainsLabels() doesn't use the schema of %q", "my_rule")
+	}
+	if ContainsLabels("my_rule", "my_inputs") {
+		t.Errorf("ContainsLabels() uses a schema of %q that is not passed to it", "my_rule")
+	}
+}
+
+func TestGetParser(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileType build.FileType
+	}{
+		{"BUILD", build.TypeBuild},
+		{"pkg/BUILD.bazel", build.TypeBuild},
+		{"pkg/BUCK", build.TypeBuild},
+		{"pkg/BUILD.tools", build.TypeBuild},
+		{"WORKSPACE", build.TypeWorkspace},
+		{"WORKSPACE.bazel", build.TypeWorkspace},
+		{"REPO.bazel", build.TypeDefault},
+		{stdinPackageName, build.TypeBuild},
+		{"MODULE.bazel", build.TypeModule},
+		{"pkg/defs.bzl", build.TypeBzl},
+	}
+	for _, tt := range tests {
+		f, err := getParser(tt.name)(tt.name, []byte{})
+		if err != nil {
+			t.Errorf("getParser(%q): %v", tt.name, err)
+			continue
+		}
+		if f.Type != tt.fileType {
+			t.Errorf("getParser(%q) parses the file as %s, want %s", tt.name, f.Type, tt.fileType)
+		}
+	}
+}
+
+func TestRepoMapping(t *testing.T) {
+	mapping := labels.NewRepoMapping(map[string]string{
+		"my_module": "",
+		"rules_go":  "rules_go+",
+	})
+
+	bld, err := build.Parse("pkg/BUILD", []byte(`go_library(
+    name = "a",
+    deps = [
+        "//other:b",
+        "@rules_go//go:lib",
+        "@rules_go//go:old",
+    ],
+)`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := bld.Rules("")[0]
+	for _, tc := range []struct {
+		fn   func(*Options, CmdEnvironment) (*build.File, error)
+		args []string
+	}{
+		{cmdRemove, []string{"deps", "@my_module//other:b"}},
+		{cmdRemove, []string{"deps", "@@rules_go+//go:lib"}},
+		{cmdAdd, []string{"deps", "@my_module//pkg:c", "@@rules_go+//go:d"}},
+		{cmdReplace, []string{"deps", "@@rules_go+//go:old", "@@rules_go+//go:new"}},
+	} {
+		env := CmdEnvironment{File: bld, Rule: rule, Pkg: "pkg", Args: tc.args, RepoMapping: mapping}
+		if _, err := tc.fn(NewOpts(), env); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := `go_library(
+    name = "a",
+    deps = [
+        ":c",
+        "@rules_go//go:d",
+        "@rules_go//go:new",
+    ],
+)`
+	if got := strings.TrimSpace(string(build.Format(bld))); got != expected {
+		t.Errorf("commands with a repository mapping:\ngot:\n%s\nexpected:\n%s", got, expected)
+	}
+}
@@ -20,14 +20,14 @@ func (b *defaultBuildifier) Buildify(opts *Options, f *build.File) ([]byte, erro
 		// value is a chunk of code, like "f(x)". The AST should be printed and
 		// re-read to parse such expressions correctly.
 		contents := build.Format(f)
-		newF, err := build.ParseBuild(f.Path, []byte(contents))
+		newF, err := getParserForType(f.Type)(f.Path, []byte(contents))
 		if err != nil {
 			return nil, err
 		}
 		return build.Format(newF), nil
 	}
 
-	cmd := exec.Command(opts.Buildifier, "--type=build")
+	cmd := exec.Command(opts.Buildifier, "--type="+buildifierInputType(f.Type))
 	data := build.Format(f)
 	cmd.Stdin = bytes.NewBuffer(data)
 	stdout := bytes.NewBuffer(nil)
@@ -47,3 +47,19 @@ func (b *defaultBuildifier) Buildify(opts *Options, f *build.File) ([]byte, erro
 	}
 	return stdout.Bytes(), nil
 }
+
+// buildifierInputType returns the value of the buildifier `--type` flag for the given file type.
+func buildifierInputType(fileType build.FileType) string {
+	switch fileType {
+	case build.TypeWorkspace:
+		return "workspace"
+	case build.TypeModule:
+		return "module"
+	case build.TypeBzl:
+		return "bzl"
+	case build.TypeDefault:
+		return "default"
+	default:
+		return "build"
+	}
+}
@@ -73,6 +73,12 @@ func InterpretLabelForWorkspaceLocation(root string, target string) (buildFile s
 
 	defaultBuildFileName := "BUILD"
 	if strings.HasPrefix(target, "//") {
+		if fileName, fileRule, ok := splitFileTarget(rule); ok {
+			// "//pkg:MODULE.bazel:rule" refers to a rule in a non-BUILD file of the package
+			if file := filepath.Join(rootDir, pkg, fileName); isFile(file) {
+				return file, pkg, fileRule
+			}
+		}
 		for _, buildFileName := range BuildFileNames {
 			buildFile = filepath.Join(rootDir, pkg, buildFileName)
 			if isFile(buildFile) {
@@ -88,6 +94,11 @@ func InterpretLabelForWorkspaceLocation(root string, target string) (buildFile s
 		pkg = filepath.Join(relativePath, filepath.Dir(pkg))
 		return
 	}
+	if fileName, fileRule, ok := splitFileTarget(rule); ok {
+		if file := filepath.Join(pkg, fileName); isFile(file) {
+			return file, filepath.Join(relativePath, pkg), fileRule
+		}
+	}
 
 	found := false
 	for _, buildFileName := range BuildFileNames {
@@ -105,6 +116,16 @@ func InterpretLabelForWorkspaceLocation(root string, target string) (buildFile s
 	return
 }
 
+// splitFileTarget splits a target name of the form "<file>:<rule>" (e.g. "MODULE.bazel:%bazel_dep")
+// into the file name and the rule name.
+func splitFileTarget(target string) (fileName, rule string, ok bool) {
+	i := strings.Index(target, ":")
+	if i <= 0 || i == len(target)-1 {
+		return "", "", false
+	}
+	return target[:i], target[i+1:], true
+}
+
 // InterpretLabel returns the name of the BUILD file to edit, the full
 // package name, and the rule. It uses the pwd for resolving workspace file paths.
 func InterpretLabel(target string) (buildFile string, pkg string, rule string) {
@@ -181,7 +202,7 @@ func RemoveEmptyPackage(f *build.File) *build.File {
 		}
 		all = append(all, stmt)
 	}
-	return &build.File{Path: f.Path, Comments: f.Comments, Stmt: all, Type: build.TypeBuild}
+	return &build.File{Path: f.Path, Comments: f.Comments, Stmt: all, Type: f.Type}
 }
 
 // InsertAfter inserts an expression after index i.
@@ -298,7 +319,7 @@ func DeleteRule(f *build.File, rule *build.Rule) *build.File {
 		}
 		all = append(all, stmt)
 	}
-	return &build.File{Path: f.Path, Comments: f.Comments, Stmt: all, Type: build.TypeBuild}
+	return &build.File{Path: f.Path, Comments: f.Comments, Stmt: all, Type: f.Type}
 }
 
 // DeleteRuleByName returns the AST without the rules that have the
@@ -316,7 +337,7 @@ func DeleteRuleByName(f *build.File, name string) *build.File {
 			all = append(all, stmt)
 		}
 	}
-	return &build.File{Path: f.Path, Comments: f.Comments, Stmt: all, Type: build.TypeBuild}
+	return &build.File{Path: f.Path, Comments: f.Comments, Stmt: all, Type: f.Type}
 }
 
 // DeleteRuleByKind removes the rules of the specified kind from the AST.
@@ -334,7 +355,7 @@ func DeleteRuleByKind(f *build.File, kind string) *build.File {
 			all = append(all, stmt)
 		}
 	}
-	return &build.File{Path: f.Path, Comments: f.Comments, Stmt: all, Type: build.TypeBuild}
+	return &build.File{Path: f.Path, Comments: f.Comments, Stmt: all, Type: f.Type}
 }
 
 // AllLists returns all the lists concatenated in an expression.
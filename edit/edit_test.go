@@ -739,12 +739,18 @@ func runTestInterpretLabelForWorkspaceLocation(t *testing.T, buildFileName strin
 	if err := ioutil.WriteFile(filepath.Join(tmp, "WORKSPACE"), nil, 0755); err != nil {
 		t.Fatal(err)
 	}
+	if err := ioutil.WriteFile(filepath.Join(tmp, "MODULE.bazel"), nil, 0755); err != nil {
+		t.Fatal(err)
+	}
 	if err := ioutil.WriteFile(filepath.Join(tmp, buildFileName), nil, 0755); err != nil {
 		t.Fatal(err)
 	}
 	if err := ioutil.WriteFile(filepath.Join(tmp, "a", buildFileName), nil, 0755); err != nil {
 		t.Fatal(err)
 	}
+	if err := ioutil.WriteFile(filepath.Join(tmp, "a", "defs.bzl"), nil, 0755); err != nil {
+		t.Fatal(err)
+	}
 	if err := ioutil.WriteFile(filepath.Join(tmp, "a", "b", buildFileName), nil, 0755); err != nil {
 		t.Fatal(err)
 	}
@@ -755,6 +761,10 @@ func runTestInterpretLabelForWorkspaceLocation(t *testing.T, buildFileName strin
 		{tmp, "//a:a", filepath.Join(tmp, "a", buildFileName), "a", "a"},
 		{tmp, "//a/b", filepath.Join(tmp, "a", "b", buildFileName), "a/b", "b"},
 		{tmp, "//a/b:b", filepath.Join(tmp, "a", "b", buildFileName), "a/b", "b"},
+		{tmp, "//:MODULE.bazel:rules_go", filepath.Join(tmp, "MODULE.bazel"), "", "rules_go"},
+		{tmp, "//:MODULE.bazel:%bazel_dep", filepath.Join(tmp, "MODULE.bazel"), "", "%bazel_dep"},
+		{tmp, "//a:defs.bzl:foo", filepath.Join(tmp, "a", "defs.bzl"), "a", "foo"},
+		{tmp, "//a:missing.bzl:foo", filepath.Join(tmp, "a", buildFileName), "a", "missing.bzl:foo"},
 	} {
 		buildFile, pkg, rule := InterpretLabelForWorkspaceLocation(tc.inputRoot, tc.inputTarget)
 		if buildFile != tc.expectedBuildFile || pkg != tc.expectedPkg || rule != tc.expectedRule {
@@ -0,0 +1,173 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Resolution of targets that are not declared literally in a BUILD file.
+
+package edit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+)
+
+// ParseMacroSuffixes parses a list of `kind=suffix` entries (e.g. "my_macro=_lib")
+// into a map from macro kinds to the suffixes of the target names they generate.
+// Several entries may be given for the same kind.
+func ParseMacroSuffixes(entries []string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	for _, entry := range entries {
+		i := strings.Index(entry, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid macro suffix %q, expected <kind>=<suffix>", entry)
+		}
+		kind, suffix := entry[:i], entry[i+1:]
+		result[kind] = append(result[kind], suffix)
+	}
+	return result, nil
+}
+
+// FindGeneratingRule returns the call site that generates the target `name` if the
+// target is not declared literally in the file, or nil if no such call site is found.
+//
+// Two kinds of call sites are recognized:
+//   - a rule inside a list comprehension over a literal list, e.g.
+//     `[cc_test(name = n + "_test") for n in ["a", "b"]]` generates "a_test" and "b_test";
+//   - a macro call whose name concatenated with one of the suffixes registered for its kind
+//     in `macroSuffixes` equals `name`, e.g. `my_macro(name = "x")` generates "x_lib" if
+//     macroSuffixes["my_macro"] contains "_lib".
+func FindGeneratingRule(f *build.File, name string, macroSuffixes map[string][]string) *build.Rule {
+	for _, stmt := range f.Stmt {
+		comp, ok := stmt.(*build.Comprehension)
+		if !ok {
+			continue
+		}
+		call, ok := comp.Body.(*build.CallExpr)
+		if !ok {
+			continue
+		}
+		for _, generated := range comprehensionRuleNames(call, comp.Clauses) {
+			if generated == name {
+				return f.Rule(call)
+			}
+		}
+	}
+
+	if len(macroSuffixes) == 0 {
+		return nil
+	}
+	for _, stmt := range f.Stmt {
+		call, ok := stmt.(*build.CallExpr)
+		if !ok {
+			continue
+		}
+		r := f.Rule(call)
+		ruleName := r.Name()
+		if ruleName == "" {
+			continue
+		}
+		for _, suffix := range macroSuffixes[r.Kind()] {
+			if ruleName+suffix == name {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+// comprehensionRuleNames returns the names of all targets generated by a rule call
+// inside a list comprehension. Only comprehensions consisting of a single `for` clause
+// iterating over a literal list of strings are supported, other comprehensions are ignored.
+func comprehensionRuleNames(call *build.CallExpr, clauses []build.Expr) []string {
+	if len(clauses) != 1 {
+		return nil
+	}
+	clause, ok := clauses[0].(*build.ForClause)
+	if !ok {
+		return nil
+	}
+	loopVar, ok := clause.Vars.(*build.Ident)
+	if !ok {
+		return nil
+	}
+	list, ok := clause.X.(*build.ListExpr)
+	if !ok {
+		return nil
+	}
+	nameExpr := build.NewRule(call).Attr("name")
+	if nameExpr == nil {
+		return nil
+	}
+
+	var names []string
+	for _, item := range list.List {
+		value, ok := item.(*build.StringExpr)
+		if !ok {
+			continue
+		}
+		env := map[string]string{loopVar.Name: value.Value}
+		if name, ok := evalStringExpr(nameExpr, env); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// evalStringExpr statically evaluates a string expression that consists of string literals,
+// variables defined in `env`, concatenations and `%s` formatting.
+func evalStringExpr(expr build.Expr, env map[string]string) (string, bool) {
+	switch expr := expr.(type) {
+	case *build.StringExpr:
+		return expr.Value, true
+	case *build.Ident:
+		value, ok := env[expr.Name]
+		return value, ok
+	case *build.ParenExpr:
+		return evalStringExpr(expr.X, env)
+	case *build.BinaryExpr:
+		x, ok := evalStringExpr(expr.X, env)
+		if !ok {
+			return "", false
+		}
+		switch expr.Op {
+		case "+":
+			y, ok := evalStringExpr(expr.Y, env)
+			if !ok {
+				return "", false
+			}
+			return x + y, true
+		case "%":
+			args := []build.Expr{expr.Y}
+			if tuple, ok := expr.Y.(*build.TupleExpr); ok {
+				args = tuple.List
+			}
+			for _, arg := range args {
+				value, ok := evalStringExpr(arg, env)
+				if !ok || !strings.Contains(x, "%s") {
+					return "", false
+				}
+				x = strings.Replace(x, "%s", value, 1)
+			}
+			if strings.Contains(x, "%") {
+				// Unsupported format directives
+				return "", false
+			}
+			return x, true
+		}
+	}
+	return "", false
+}
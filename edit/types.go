@@ -26,6 +26,37 @@ import (
 
 var typeOf = lang.TypeOf
 
+// moduleTypeOf contains the types of arguments of MODULE.bazel functions
+// (module, bazel_dep, *_override, etc.). Other arguments are considered strings.
+var moduleTypeOf = map[string]buildpb.Attribute_Discriminator{
+	"bazel_compatibility":     buildpb.Attribute_STRING_LIST,
+	"compatibility_level":     buildpb.Attribute_INTEGER,
+	"dev_dependency":          buildpb.Attribute_BOOLEAN,
+	"execution_platforms":     buildpb.Attribute_STRING_LIST,
+	"max_compatibility_level": buildpb.Attribute_INTEGER,
+	"patch_cmds":              buildpb.Attribute_STRING_LIST,
+	"patch_strip":             buildpb.Attribute_INTEGER,
+	"patches":                 buildpb.Attribute_LABEL_LIST,
+	"toolchains":              buildpb.Attribute_STRING_LIST,
+	"urls":                    buildpb.Attribute_STRING_LIST,
+	"versions":                buildpb.Attribute_STRING_LIST,
+}
+
+// RuleSchemas contains the attribute schemas of Starlark rules and macros available in a file,
+// indexed by their kinds. For rules of these kinds the schemas take precedence over the global
+// attribute tables. A nil value is valid and contains no schemas.
+type RuleSchemas map[string]map[string]lang.AttributeSchema
+
+// attributeType returns the type of an attribute from the schema of the rule kind.
+// Returns false if there's no schema of the rule kind or it doesn't know the type of the attribute.
+func (schemas RuleSchemas) attributeType(kind, attr string) (buildpb.Attribute_Discriminator, bool) {
+	schema, ok := schemas[kind][attr]
+	if !ok || schema.Type == buildpb.Attribute_UNKNOWN {
+		return buildpb.Attribute_UNKNOWN, false
+	}
+	return schema.Type, true
+}
+
 // IsList returns true for all attributes whose type is a list.
 func IsList(attr string) bool {
 	overrideValue, isOverridden := tables.IsListArg[attr]
@@ -37,7 +68,18 @@ func IsList(attr string) bool {
 	if isSortableList {
 		return true
 	}
-	ty := typeOf[attr]
+	return isListType(typeOf[attr])
+}
+
+// IsList is like the function IsList, but uses the schema of the rule kind if it's available.
+func (schemas RuleSchemas) IsList(kind, attr string) bool {
+	if ty, ok := schemas.attributeType(kind, attr); ok {
+		return isListType(ty)
+	}
+	return IsList(attr)
+}
+
+func isListType(ty buildpb.Attribute_Discriminator) bool {
 	return ty == buildpb.Attribute_STRING_LIST ||
 		ty == buildpb.Attribute_LABEL_LIST ||
 		ty == buildpb.Attribute_OUTPUT_LIST ||
@@ -52,9 +94,28 @@ func IsIntList(attr string) bool {
 	return typeOf[attr] == buildpb.Attribute_INTEGER_LIST
 }
 
+// IsIntList is like the function IsIntList, but uses the schema of the rule kind if it's available.
+func (schemas RuleSchemas) IsIntList(kind, attr string) bool {
+	if ty, ok := schemas.attributeType(kind, attr); ok {
+		return ty == buildpb.Attribute_INTEGER_LIST
+	}
+	return IsIntList(attr)
+}
+
 // IsString returns true for all attributes whose type is a string or a label.
 func IsString(attr string) bool {
-	ty := typeOf[attr]
+	return isStringType(typeOf[attr])
+}
+
+// IsString is like the function IsString, but uses the schema of the rule kind if it's available.
+func (schemas RuleSchemas) IsString(kind, attr string) bool {
+	if ty, ok := schemas.attributeType(kind, attr); ok {
+		return isStringType(ty)
+	}
+	return IsString(attr)
+}
+
+func isStringType(ty buildpb.Attribute_Discriminator) bool {
 	return ty == buildpb.Attribute_LABEL ||
 		ty == buildpb.Attribute_STRING ||
 		ty == buildpb.Attribute_OUTPUT
@@ -67,11 +128,33 @@ func IsStringDict(attr string) bool {
 
 // ContainsLabels returns true for all attributes whose type is a label or a label list.
 func ContainsLabels(kind, attr string) bool {
+	return RuleSchemas(nil).ContainsLabels(kind, attr)
+}
+
+// ContainsLabels is like the function ContainsLabels, but uses the schema of the rule kind
+// if it's available.
+func (schemas RuleSchemas) ContainsLabels(kind, attr string) bool {
 	if kind == "package_group" && attr == "packages" {
 		// "package_group" is a special rule and its "packages" attribute is not a list of labels.
 		return false
 	}
-	ty := typeOf[attr]
+	ty, ok := schemas.attributeType(kind, attr)
+	if !ok {
+		ty = typeOf[attr]
+	}
 	return ty == buildpb.Attribute_LABEL_LIST ||
 		ty == buildpb.Attribute_LABEL
 }
+
+// IsModuleList returns true for all arguments of MODULE.bazel functions whose type is a list.
+func IsModuleList(attr string) bool {
+	ty := moduleTypeOf[attr]
+	return ty == buildpb.Attribute_STRING_LIST ||
+		ty == buildpb.Attribute_LABEL_LIST
+}
+
+// IsModuleString returns true for all arguments of MODULE.bazel functions whose type is a string.
+func IsModuleString(attr string) bool {
+	_, ok := moduleTypeOf[attr]
+	return !ok
+}
@@ -15,7 +15,8 @@ limitations under the License.
 */
 
 // generateTables is a tool that generates a go file from the Build language proto file.
-// It generates a Go map to find the type of an attribute.
+// It generates a Go map to find the type of an attribute, and the schemas of the
+// attributes of native rules.
 
 package main
 
@@ -26,6 +27,7 @@ import (
 	"log"
 	"os"
 	"sort"
+	"strings"
 
 	buildpb "github.com/bazelbuild/buildtools/build_proto"
 	"github.com/golang/protobuf/proto"
@@ -82,6 +84,93 @@ func generateTable(rules []*buildpb.RuleDefinition) map[string]buildpb.Attribute
 	return types
 }
 
+// commonAttributes are accepted by all rules, but not always listed in the proto file.
+var commonAttributes = map[string]buildpb.Attribute_Discriminator{
+	"applicable_licenses":    buildpb.Attribute_LABEL_LIST,
+	"compatible_with":        buildpb.Attribute_LABEL_LIST,
+	"deprecation":            buildpb.Attribute_STRING,
+	"distribs":               buildpb.Attribute_DISTRIBUTION_SET,
+	"exec_compatible_with":   buildpb.Attribute_LABEL_LIST,
+	"exec_properties":        buildpb.Attribute_STRING_DICT,
+	"features":               buildpb.Attribute_STRING_LIST,
+	"licenses":               buildpb.Attribute_LICENSE,
+	"package_metadata":       buildpb.Attribute_LABEL_LIST,
+	"restricted_to":          buildpb.Attribute_LABEL_LIST,
+	"tags":                   buildpb.Attribute_STRING_LIST,
+	"target_compatible_with": buildpb.Attribute_LABEL_LIST,
+	"testonly":               buildpb.Attribute_BOOLEAN,
+	"toolchains":             buildpb.Attribute_LABEL_LIST,
+	"visibility":             buildpb.Attribute_STRING_LIST,
+}
+
+// commonTestAttributes are accepted by all test rules.
+var commonTestAttributes = map[string]buildpb.Attribute_Discriminator{
+	"args":        buildpb.Attribute_STRING_LIST,
+	"env":         buildpb.Attribute_STRING_DICT,
+	"env_inherit": buildpb.Attribute_STRING_LIST,
+	"flaky":       buildpb.Attribute_BOOLEAN,
+	"local":       buildpb.Attribute_BOOLEAN,
+	"shard_count": buildpb.Attribute_INTEGER,
+	"size":        buildpb.Attribute_STRING,
+	"timeout":     buildpb.Attribute_STRING,
+}
+
+// attributeSchema is the type and the mandatory flag of an attribute of a rule.
+type attributeSchema struct {
+	typ       buildpb.Attribute_Discriminator
+	mandatory bool
+}
+
+// generateRuleSchemas returns the schemas of attributes for each rule.
+// Implicit and late-bound attributes (which can't be set explicitly) are skipped.
+func generateRuleSchemas(rules []*buildpb.RuleDefinition) map[string]map[string]attributeSchema {
+	schemas := make(map[string]map[string]attributeSchema)
+	for _, r := range rules {
+		attrs := make(map[string]attributeSchema)
+		for _, attr := range r.Attribute {
+			if strings.HasPrefix(*attr.Name, "$") || strings.HasPrefix(*attr.Name, ":") {
+				continue
+			}
+			attrs[*attr.Name] = attributeSchema{*attr.Type, *attr.Mandatory}
+		}
+		common := []map[string]buildpb.Attribute_Discriminator{commonAttributes}
+		if strings.HasSuffix(*r.Name, "_test") {
+			common = append(common, commonTestAttributes)
+		}
+		for _, m := range common {
+			for name, typ := range m {
+				if _, ok := attrs[name]; !ok {
+					attrs[name] = attributeSchema{typ, false}
+				}
+			}
+		}
+		attrs["name"] = attributeSchema{buildpb.Attribute_STRING, true}
+		schemas[*r.Name] = attrs
+	}
+	return schemas
+}
+
+// sortedKeys returns the keys of a map in the lexicographical order.
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]buildpb.Attribute_Discriminator:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]map[string]attributeSchema:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]attributeSchema:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func main() {
 	flag.Parse()
 	if *inputPath == "" {
@@ -92,13 +181,7 @@ func main() {
 		log.Fatalf("%s\n", err)
 	}
 	types := generateTable(lang.Rule)
-
-	// sort the keys to get deterministic output
-	keys := make([]string, 0, len(types))
-	for i := range types {
-		keys = append(keys, i)
-	}
-	sort.Strings(keys)
+	schemas := generateRuleSchemas(lang.Rule)
 
 	f, err := os.Create(*outputPath)
 	if err != nil {
@@ -114,8 +197,40 @@ import buildpb "github.com/bazelbuild/buildtools/build_proto"
 
 var TypeOf = map[string]buildpb.Attribute_Discriminator{
 `)
-	for _, attr := range keys {
+	// sort the keys to get deterministic output
+	for _, attr := range sortedKeys(types) {
 		fmt.Fprintf(f, "	\"%s\":	buildpb.Attribute_%s,\n", attr, types[attr])
 	}
 	fmt.Fprintf(f, "}\n")
+
+	fmt.Fprintf(f, `
+var RuleSchemas = map[string]map[string]AttributeSchema{
+`)
+	for _, rule := range sortedKeys(schemas) {
+		fmt.Fprintf(f, "	\"%s\":	{\n", rule)
+		attrs := schemas[rule]
+		for _, attr := range sortedKeys(attrs) {
+			if attrs[attr].mandatory {
+				fmt.Fprintf(f, "		\"%s\":	{Type: buildpb.Attribute_%s, Mandatory: true},\n", attr, attrs[attr].typ)
+			} else {
+				fmt.Fprintf(f, "		\"%s\":	{Type: buildpb.Attribute_%s},\n", attr, attrs[attr].typ)
+			}
+		}
+		fmt.Fprintf(f, "	},\n")
+	}
+	fmt.Fprintf(f, "}\n")
+
+	for _, common := range []struct {
+		name  string
+		attrs map[string]buildpb.Attribute_Discriminator
+	}{
+		{"CommonAttributes", commonAttributes},
+		{"CommonTestAttributes", commonTestAttributes},
+	} {
+		fmt.Fprintf(f, "\nvar %s = map[string]AttributeSchema{\n", common.name)
+		for _, attr := range sortedKeys(common.attrs) {
+			fmt.Fprintf(f, "	\"%s\":	{Type: buildpb.Attribute_%s},\n", attr, common.attrs[attr])
+		}
+		fmt.Fprintf(f, "}\n")
+	}
 }
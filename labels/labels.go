@@ -28,6 +28,7 @@ type Label struct {
 	Repository string // Repository of the target, can be empty if the target belongs to the current repository
 	Package    string // Package of a target, can be empty for top packages
 	Target     string // Name of the target, should be always non-empty
+	Canonical  bool   // Whether Repository is a canonical repository name (e.g. "@@rules_go+//go:def.bzl")
 }
 
 // Format returns a string representation of a label. It's always absolute but
@@ -37,6 +38,9 @@ func (l Label) Format() string {
 	b := new(bytes.Buffer)
 	if l.Repository != "" {
 		b.WriteString("@")
+		if l.Canonical {
+			b.WriteString("@")
+		}
 		b.WriteString(l.Repository)
 	}
 	if l.Repository == l.Target && l.Package == "" {
@@ -63,16 +67,20 @@ func (l Label) FormatRelative(pkg string) string {
 
 // Parse parses an absolute Bazel label (eg. //devtools/buildozer:rule)
 // and returns the corresponding Label object.
+// Labels starting with "@@" refer to canonical repository names, "@@//" (as well as "@//")
+// refers to the main repository and is parsed the same way as "//".
 func Parse(target string) Label {
 	label := Label{}
 	if strings.HasPrefix(target, "@") {
+		canonical := strings.HasPrefix(target, "@@")
 		target = strings.TrimLeft(target, "@")
 		parts := strings.SplitN(target, "/", 2)
 		if len(parts) == 1 {
 			// "@foo" -> @foo//:foo
-			return Label{target, "", target}
+			return Label{Repository: target, Target: target, Canonical: canonical}
 		}
 		label.Repository = parts[0]
+		label.Canonical = canonical && label.Repository != ""
 		target = "/" + parts[1]
 	}
 	parts := strings.SplitN(target, ":", 2)
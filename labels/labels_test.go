@@ -39,6 +39,10 @@ var parseLabelTests = []struct {
 	{":label", "", "", "label"},
 	{"label", "", "", "label"},
 	{"/abs/path/to/WORKSPACE:rule", "", "/abs/path/to/WORKSPACE", "rule"},
+	{"@@r//devtools/buildozer:rule", "r", "devtools/buildozer", "rule"},
+	{"@@r+//base", "r+", "base", "base"},
+	{"@@//base:rule", "", "base", "rule"},
+	{"@@foo", "foo", "", "foo"},
 }
 
 func TestParseLabel(t *testing.T) {
@@ -76,6 +80,10 @@ var shortenLabelTests = []struct {
 	{"something else", "", "something else"},
 	{"/path/to/file", "path/to", "/path/to/file"},
 	{"\"//baz\"", "", "\"//baz\""},
+	{"@@r//devtools/buildozer:buildozer", "devtools/buildozer", "@@r//devtools/buildozer"},
+	{"@@r+//base:rule", "", "@@r+//base:rule"},
+	{"@@//base:rule", "base", ":rule"},
+	{"@@foo", "", "@@foo"},
 }
 
 func TestShortenLabel(t *testing.T) {
@@ -96,6 +104,8 @@ var labelsEqualTests = []struct {
 }{
 	{"//devtools/buildozer:rule", "rule", "devtools/buildozer", true},
 	{"//devtools/buildozer:rule", "rule:jar", "devtools", false},
+	{"@@//devtools/buildozer:rule", "rule", "devtools/buildozer", true},
+	{"@@r//devtools/buildozer:rule", "@r//devtools/buildozer:rule", "", false},
 }
 
 func TestLabelsEqual(t *testing.T) {
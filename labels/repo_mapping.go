@@ -0,0 +1,151 @@
+/*
+ * Copyright 2021 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package labels
+
+import (
+	"sort"
+	"strings"
+)
+
+// RepoMapping describes the repositories visible from a repository (usually the main one)
+// in a Bzlmod workspace. It maps apparent repository names (e.g. "@rules_go") to canonical
+// repository names (e.g. "@@rules_go+"). The canonical name of the main repository is empty.
+//
+// A nil *RepoMapping is valid and represents an empty mapping, in this case all functions
+// behave the same way as their mapping-unaware counterparts.
+type RepoMapping struct {
+	canonical map[string]string // apparent name -> canonical name
+	apparent  map[string]string // canonical name -> apparent name
+}
+
+// NewRepoMapping creates a RepoMapping from a map of apparent repository names to
+// canonical repository names, e.g. as extracted from MODULE.bazel `bazel_dep` and
+// `use_repo` statements.
+func NewRepoMapping(apparentToCanonical map[string]string) *RepoMapping {
+	m := &RepoMapping{
+		canonical: make(map[string]string),
+		apparent:  make(map[string]string),
+	}
+	// Iterate in a deterministic order so that the reverse mapping is stable if several
+	// apparent names refer to the same canonical name.
+	var names []string
+	for name := range apparentToCanonical {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		canonical := apparentToCanonical[name]
+		m.canonical[name] = canonical
+		if canonical == "" {
+			continue
+		}
+		if module := moduleName(canonical); apparentToCanonical[module] == canonical {
+			// The module's own name (as used in `bazel_dep`) is preferred to other apparent names
+			m.apparent[canonical] = module
+		} else if _, ok := m.apparent[canonical]; !ok {
+			m.apparent[canonical] = name
+		}
+	}
+	return m
+}
+
+// moduleName returns the name of the module a canonical repository name belongs to,
+// e.g. "rules_go" for "rules_go+" or "rules_go~0.1.0".
+func moduleName(canonical string) string {
+	if i := strings.IndexAny(canonical, "+~"); i >= 0 {
+		return canonical[:i]
+	}
+	return canonical
+}
+
+// Canonical returns the canonical name of the repository with the given apparent name.
+// The second returned value is false if the repository is unknown.
+func (m *RepoMapping) Canonical(apparent string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	canonical, ok := m.canonical[apparent]
+	return canonical, ok
+}
+
+// Apparent returns an apparent name of the repository with the given canonical name.
+// The second returned value is false if the repository is not visible.
+func (m *RepoMapping) Apparent(canonical string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	apparent, ok := m.apparent[canonical]
+	return apparent, ok
+}
+
+// Resolve converts a label to the canonical form: labels in the main repository have an empty
+// repository name, other labels refer to their repositories by canonical names if they're known.
+// Labels in unknown repositories are returned unchanged.
+func (m *RepoMapping) Resolve(l Label) Label {
+	if l.Canonical || l.Repository == "" {
+		return l
+	}
+	canonical, ok := m.Canonical(l.Repository)
+	if !ok {
+		return l
+	}
+	l.Repository = canonical
+	l.Canonical = canonical != ""
+	return l
+}
+
+// Unresolve converts a label to the form that's preferred in BUILD files: labels in the main
+// repository have an empty repository name, other labels use apparent repository names if
+// they're known. Labels that already use apparent repository names are not changed.
+func (m *RepoMapping) Unresolve(l Label) Label {
+	if !l.Canonical {
+		if canonical, ok := m.Canonical(l.Repository); ok && canonical == "" {
+			// The main repository
+			l.Repository = ""
+		}
+		return l
+	}
+	if apparent, ok := m.Apparent(l.Repository); ok {
+		l.Repository = apparent
+		l.Canonical = false
+	}
+	return l
+}
+
+// FormatRelative returns a string representation of a label relative to `pkg` in the main
+// repository, using apparent repository names for labels in other repositories.
+// Labels in the main repository are formatted without the repository name even if they use
+// the main repository's apparent name (e.g. "@my_module//foo:bar" becomes "//foo:bar").
+func (m *RepoMapping) FormatRelative(l Label, pkg string) string {
+	return m.Unresolve(l).FormatRelative(pkg)
+}
+
+// Shorten is a mapping-aware version of Shorten.
+func (m *RepoMapping) Shorten(input, pkg string) string {
+	if !strings.HasPrefix(input, "//") && !strings.HasPrefix(input, "@") {
+		// It doesn't look like a long label, so we preserve it.
+		return input
+	}
+	return m.FormatRelative(Parse(input), pkg)
+}
+
+// Equal is a mapping-aware version of Equal: labels are considered equal if they refer
+// to the same target, even if one of them uses an apparent and the other uses a canonical
+// repository name.
+func (m *RepoMapping) Equal(label1, label2, pkg string) bool {
+	return m.Resolve(ParseRelative(label1, pkg)) == m.Resolve(ParseRelative(label2, pkg))
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2021 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package labels
+
+import (
+	"testing"
+)
+
+var testMapping = NewRepoMapping(map[string]string{
+	"my_module":           "",
+	"rules_go":            "rules_go+",
+	"io_bazel_rules_go":   "rules_go+",
+	"com_google_protobuf": "protobuf+",
+	"rules_cc":            "rules_cc~",
+	"bazel_rules_cc":      "rules_cc~",
+})
+
+var mappingShortenTests = []struct {
+	in     string
+	pkg    string
+	result string
+}{
+	{"@my_module//foo:bar", "foo", ":bar"},
+	{"@my_module//foo:bar", "", "//foo:bar"},
+	{"@@//foo:bar", "foo", ":bar"},
+	{"@@rules_go+//go:def.bzl", "", "@rules_go//go:def.bzl"},
+	{"@rules_go//go:def.bzl", "", "@rules_go//go:def.bzl"},
+	{"@@protobuf+//:protobuf", "", "@com_google_protobuf//:protobuf"},
+	{"@@rules_cc~//cc:defs.bzl", "", "@rules_cc//cc:defs.bzl"},
+	{"@@unknown+//foo", "", "@@unknown+//foo"},
+	{"@unknown//foo", "", "@unknown//foo"},
+	{"@rules_go", "", "@rules_go"},
+	{"@@rules_go+", "", "@rules_go//:rules_go+"},
+	{":local", "", ":local"},
+	{"file.txt", "", "file.txt"},
+}
+
+func TestRepoMappingShorten(t *testing.T) {
+	for i, tt := range mappingShortenTests {
+		result := testMapping.Shorten(tt.in, tt.pkg)
+		if result != tt.result {
+			t.Errorf("%d. Shorten(%q, %q) => %q, want %q",
+				i, tt.in, tt.pkg, result, tt.result)
+		}
+	}
+}
+
+func TestNilRepoMapping(t *testing.T) {
+	var m *RepoMapping
+	for i, tt := range shortenLabelTests {
+		result := m.Shorten(tt.in, tt.pkg)
+		if result != tt.result {
+			t.Errorf("%d. Shorten(%q, %q) => %q, want %q",
+				i, tt.in, tt.pkg, result, tt.result)
+		}
+	}
+	for i, tt := range labelsEqualTests {
+		if got := m.Equal(tt.label1, tt.label2, tt.pkg); got != tt.expected {
+			t.Errorf("%d. Equal(%q, %q, %q) => %v, want %v",
+				i, tt.label1, tt.label2, tt.pkg, got, tt.expected)
+		}
+	}
+}
+
+var mappingEqualTests = []struct {
+	label1   string
+	label2   string
+	pkg      string
+	expected bool
+}{
+	{"@my_module//foo:bar", ":bar", "foo", true},
+	{"@my_module//foo:bar", "//foo:bar", "", true},
+	{"@@//foo:bar", "@my_module//foo:bar", "", true},
+	{"@rules_go//go:def.bzl", "@@rules_go+//go:def.bzl", "", true},
+	{"@rules_go//go:def.bzl", "@io_bazel_rules_go//go:def.bzl", "", true},
+	{"@rules_go//go:def.bzl", "@com_google_protobuf//go:def.bzl", "", false},
+	{"@unknown//foo", "@@unknown//foo", "", false},
+	{"@rules_go", "@@rules_go+//:rules_go", "", true},
+}
+
+func TestRepoMappingEqual(t *testing.T) {
+	for i, tt := range mappingEqualTests {
+		if got := testMapping.Equal(tt.label1, tt.label2, tt.pkg); got != tt.expected {
+			t.Errorf("%d. Equal(%q, %q, %q) => %v, want %v",
+				i, tt.label1, tt.label2, tt.pkg, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lang
+
+import buildpb "github.com/bazelbuild/buildtools/build_proto"
+
+// AttributeSchema describes an attribute of a rule, RuleSchemas contains the schemas
+// of all attributes that can be set explicitly for each native rule. CommonAttributes
+// and CommonTestAttributes are accepted by all rules and all test rules respectively.
+type AttributeSchema struct {
+	Type      buildpb.Attribute_Discriminator
+	Mandatory bool
+	Default   string // the default value formatted as Starlark code, empty if unknown
+}
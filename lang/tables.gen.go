@@ -430,3 +430,2347 @@ var TypeOf = map[string]buildpb.Attribute_Discriminator{
 	"xlint":	buildpb.Attribute_STRING_LIST,
 	"zipalign":	buildpb.Attribute_LABEL,
 }
+
+var RuleSchemas = map[string]map[string]AttributeSchema{
+	"aar_import":	{
+		"aar":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"exports":	{Type: buildpb.Attribute_LABEL_LIST},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcjar":	{Type: buildpb.Attribute_LABEL},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"action_listener":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"extra_actions":	{Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"mnemonics":	{Type: buildpb.Attribute_STRING_LIST, Mandatory: true},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"alias":	{
+		"actual":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"android_binary":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"application_resources":	{Type: buildpb.Attribute_LABEL},
+		"assets":	{Type: buildpb.Attribute_LABEL_LIST},
+		"assets_dir":	{Type: buildpb.Attribute_STRING},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"crunch_png":	{Type: buildpb.Attribute_BOOLEAN},
+		"custom_package":	{Type: buildpb.Attribute_STRING},
+		"debug_key":	{Type: buildpb.Attribute_LABEL},
+		"debug_signing_keys":	{Type: buildpb.Attribute_LABEL_LIST},
+		"debug_signing_lineage_file":	{Type: buildpb.Attribute_LABEL},
+		"densities":	{Type: buildpb.Attribute_STRING_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"dex_shards":	{Type: buildpb.Attribute_INTEGER},
+		"dexopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"enable_data_binding":	{Type: buildpb.Attribute_BOOLEAN},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"feature_flags":	{Type: buildpb.Attribute_LABEL_KEYED_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"incremental_dexing":	{Type: buildpb.Attribute_TRISTATE},
+		"inline_constants":	{Type: buildpb.Attribute_BOOLEAN},
+		"instruments":	{Type: buildpb.Attribute_LABEL},
+		"javacopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"main_dex_list":	{Type: buildpb.Attribute_LABEL},
+		"main_dex_list_opts":	{Type: buildpb.Attribute_STRING_LIST},
+		"main_dex_proguard_specs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"manifest":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"manifest_values":	{Type: buildpb.Attribute_STRING_DICT},
+		"multidex":	{Type: buildpb.Attribute_STRING},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"nocompress_extensions":	{Type: buildpb.Attribute_STRING_LIST},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"plugins":	{Type: buildpb.Attribute_LABEL_LIST},
+		"proguard_apply_dictionary":	{Type: buildpb.Attribute_LABEL},
+		"proguard_apply_mapping":	{Type: buildpb.Attribute_LABEL},
+		"proguard_generate_mapping":	{Type: buildpb.Attribute_BOOLEAN},
+		"proguard_specs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"resource_configuration_filters":	{Type: buildpb.Attribute_STRING_LIST},
+		"resource_files":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"shrink_resources":	{Type: buildpb.Attribute_TRISTATE},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"android_device":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"cache":	{Type: buildpb.Attribute_INTEGER, Mandatory: true},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"default_properties":	{Type: buildpb.Attribute_LABEL},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"horizontal_resolution":	{Type: buildpb.Attribute_INTEGER, Mandatory: true},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"platform_apks":	{Type: buildpb.Attribute_LABEL_LIST},
+		"pregenerate_oat_files_for_tests":	{Type: buildpb.Attribute_BOOLEAN},
+		"ram":	{Type: buildpb.Attribute_INTEGER, Mandatory: true},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"screen_density":	{Type: buildpb.Attribute_INTEGER, Mandatory: true},
+		"system_image":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"vertical_resolution":	{Type: buildpb.Attribute_INTEGER, Mandatory: true},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+		"vm_heap":	{Type: buildpb.Attribute_INTEGER, Mandatory: true},
+	},
+	"android_device_script_fixture":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"cmd":	{Type: buildpb.Attribute_STRING},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"daemon":	{Type: buildpb.Attribute_BOOLEAN},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"script":	{Type: buildpb.Attribute_LABEL},
+		"strict_exit":	{Type: buildpb.Attribute_BOOLEAN},
+		"support_apks":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"android_host_service_fixture":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"daemon":	{Type: buildpb.Attribute_BOOLEAN},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"executable":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"provides_test_args":	{Type: buildpb.Attribute_BOOLEAN},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"service_names":	{Type: buildpb.Attribute_STRING_LIST},
+		"support_apks":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"android_instrumentation_test":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"args":	{Type: buildpb.Attribute_STRING_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"env":	{Type: buildpb.Attribute_STRING_DICT},
+		"env_inherit":	{Type: buildpb.Attribute_STRING_LIST},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"fixtures":	{Type: buildpb.Attribute_LABEL_LIST},
+		"flaky":	{Type: buildpb.Attribute_BOOLEAN},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"local":	{Type: buildpb.Attribute_BOOLEAN},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"shard_count":	{Type: buildpb.Attribute_INTEGER},
+		"size":	{Type: buildpb.Attribute_STRING},
+		"support_apks":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"target_device":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"test_app":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"timeout":	{Type: buildpb.Attribute_STRING},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"android_library":	{
+		"alwayslink":	{Type: buildpb.Attribute_BOOLEAN},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"assets":	{Type: buildpb.Attribute_LABEL_LIST},
+		"assets_dir":	{Type: buildpb.Attribute_STRING},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"custom_package":	{Type: buildpb.Attribute_STRING},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"enable_data_binding":	{Type: buildpb.Attribute_BOOLEAN},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"exported_plugins":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exports":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exports_manifest":	{Type: buildpb.Attribute_TRISTATE},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"idl_import_root":	{Type: buildpb.Attribute_STRING},
+		"idl_parcelables":	{Type: buildpb.Attribute_LABEL_LIST},
+		"idl_preprocessed":	{Type: buildpb.Attribute_LABEL_LIST},
+		"idl_srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"inline_constants":	{Type: buildpb.Attribute_BOOLEAN},
+		"javacopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"manifest":	{Type: buildpb.Attribute_LABEL},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"neverlink":	{Type: buildpb.Attribute_BOOLEAN},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"plugins":	{Type: buildpb.Attribute_LABEL_LIST},
+		"proguard_specs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"resource_files":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"android_local_test":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"args":	{Type: buildpb.Attribute_STRING_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"custom_package":	{Type: buildpb.Attribute_STRING},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"densities":	{Type: buildpb.Attribute_STRING_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"env":	{Type: buildpb.Attribute_STRING_DICT},
+		"env_inherit":	{Type: buildpb.Attribute_STRING_LIST},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"feature_flags":	{Type: buildpb.Attribute_LABEL_KEYED_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"flaky":	{Type: buildpb.Attribute_BOOLEAN},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"javacopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"jvm_flags":	{Type: buildpb.Attribute_STRING_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"local":	{Type: buildpb.Attribute_BOOLEAN},
+		"manifest":	{Type: buildpb.Attribute_LABEL},
+		"manifest_values":	{Type: buildpb.Attribute_STRING_DICT},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"nocompress_extensions":	{Type: buildpb.Attribute_STRING_LIST},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"plugins":	{Type: buildpb.Attribute_LABEL_LIST},
+		"resource_configuration_filters":	{Type: buildpb.Attribute_STRING_LIST},
+		"resource_jars":	{Type: buildpb.Attribute_LABEL_LIST},
+		"resource_strip_prefix":	{Type: buildpb.Attribute_STRING},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"runtime_deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"shard_count":	{Type: buildpb.Attribute_INTEGER},
+		"size":	{Type: buildpb.Attribute_STRING},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"stamp":	{Type: buildpb.Attribute_TRISTATE},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"test_class":	{Type: buildpb.Attribute_STRING},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"timeout":	{Type: buildpb.Attribute_STRING},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"android_ndk_repository":	{
+		"api_level":	{Type: buildpb.Attribute_INTEGER},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"path":	{Type: buildpb.Attribute_STRING},
+		"repo_mapping":	{Type: buildpb.Attribute_STRING_DICT},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"android_sdk":	{
+		"aapt":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"aapt2":	{Type: buildpb.Attribute_LABEL},
+		"adb":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"aidl":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"aidl_lib":	{Type: buildpb.Attribute_LABEL},
+		"android_jar":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"annotations_jar":	{Type: buildpb.Attribute_LABEL},
+		"apkbuilder":	{Type: buildpb.Attribute_LABEL},
+		"apksigner":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"build_tools_version":	{Type: buildpb.Attribute_STRING},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"dx":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"framework_aidl":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"main_dex_classes":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"main_dex_list_creator":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"proguard":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"shrinked_android_jar":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"source_properties":	{Type: buildpb.Attribute_LABEL},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+		"zipalign":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+	},
+	"android_sdk_repository":	{
+		"api_level":	{Type: buildpb.Attribute_INTEGER},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"build_tools_version":	{Type: buildpb.Attribute_STRING},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"path":	{Type: buildpb.Attribute_STRING},
+		"repo_mapping":	{Type: buildpb.Attribute_STRING_DICT},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"android_tools_defaults_jar":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"apple_binary":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"binary_type":	{Type: buildpb.Attribute_STRING},
+		"bundle_loader":	{Type: buildpb.Attribute_LABEL},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"dylibs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"extension_safe":	{Type: buildpb.Attribute_BOOLEAN},
+		"feature_flags":	{Type: buildpb.Attribute_LABEL_KEYED_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"linkopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"minimum_os_version":	{Type: buildpb.Attribute_STRING},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"platform_type":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"sdk_dylibs":	{Type: buildpb.Attribute_STRING_LIST},
+		"sdk_frameworks":	{Type: buildpb.Attribute_STRING_LIST},
+		"stamp":	{Type: buildpb.Attribute_TRISTATE},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+		"weak_sdk_frameworks":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"apple_cc_toolchain":	{
+		"all_files":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"ar_files":	{Type: buildpb.Attribute_LABEL},
+		"as_files":	{Type: buildpb.Attribute_LABEL},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compiler":	{Type: buildpb.Attribute_STRING},
+		"compiler_files":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"compiler_files_without_includes":	{Type: buildpb.Attribute_LABEL},
+		"coverage_files":	{Type: buildpb.Attribute_LABEL},
+		"cpu":	{Type: buildpb.Attribute_STRING},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"dwp_files":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"dynamic_runtime_lib":	{Type: buildpb.Attribute_LABEL},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"libc_top":	{Type: buildpb.Attribute_LABEL},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"linker_files":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"module_map":	{Type: buildpb.Attribute_LABEL},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"objcopy_files":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"output_licenses":	{Type: buildpb.Attribute_LICENSE},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"static_runtime_lib":	{Type: buildpb.Attribute_LABEL},
+		"strip_files":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"supports_header_parsing":	{Type: buildpb.Attribute_BOOLEAN},
+		"supports_param_files":	{Type: buildpb.Attribute_BOOLEAN},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchain_config":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"toolchain_identifier":	{Type: buildpb.Attribute_STRING},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"apple_static_library":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"avoid_deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"feature_flags":	{Type: buildpb.Attribute_LABEL_KEYED_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"linkopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"minimum_os_version":	{Type: buildpb.Attribute_STRING},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"platform_type":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"sdk_dylibs":	{Type: buildpb.Attribute_STRING_LIST},
+		"sdk_frameworks":	{Type: buildpb.Attribute_STRING_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+		"weak_sdk_frameworks":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"available_xcodes":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"default":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"versions":	{Type: buildpb.Attribute_LABEL_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"bind":	{
+		"actual":	{Type: buildpb.Attribute_LABEL},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"cc_binary":	{
+		"additional_linker_inputs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"args":	{Type: buildpb.Attribute_STRING_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"copts":	{Type: buildpb.Attribute_STRING_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"defines":	{Type: buildpb.Attribute_STRING_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"dynamic_deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"includes":	{Type: buildpb.Attribute_STRING_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"linkopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"linkshared":	{Type: buildpb.Attribute_BOOLEAN},
+		"linkstatic":	{Type: buildpb.Attribute_BOOLEAN},
+		"local_defines":	{Type: buildpb.Attribute_STRING_LIST},
+		"malloc":	{Type: buildpb.Attribute_LABEL},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"nocopts":	{Type: buildpb.Attribute_STRING},
+		"output_licenses":	{Type: buildpb.Attribute_LICENSE},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"reexport_deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"stamp":	{Type: buildpb.Attribute_TRISTATE},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+		"win_def_file":	{Type: buildpb.Attribute_LABEL},
+	},
+	"cc_host_toolchain_alias":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"cc_import":	{
+		"alwayslink":	{Type: buildpb.Attribute_BOOLEAN},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"hdrs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"interface_library":	{Type: buildpb.Attribute_LABEL},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"shared_library":	{Type: buildpb.Attribute_LABEL},
+		"static_library":	{Type: buildpb.Attribute_LABEL},
+		"system_provided":	{Type: buildpb.Attribute_BOOLEAN},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"cc_libc_top_alias":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"cc_library":	{
+		"alwayslink":	{Type: buildpb.Attribute_BOOLEAN},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"copts":	{Type: buildpb.Attribute_STRING_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"defines":	{Type: buildpb.Attribute_STRING_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"hdrs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"include_prefix":	{Type: buildpb.Attribute_STRING},
+		"includes":	{Type: buildpb.Attribute_STRING_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"linkopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"linkstamp":	{Type: buildpb.Attribute_LABEL},
+		"linkstatic":	{Type: buildpb.Attribute_BOOLEAN},
+		"local_defines":	{Type: buildpb.Attribute_STRING_LIST},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"nocopts":	{Type: buildpb.Attribute_STRING},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"reexport_deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"strip_include_prefix":	{Type: buildpb.Attribute_STRING},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"textual_hdrs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+		"win_def_file":	{Type: buildpb.Attribute_LABEL},
+	},
+	"cc_proto_library":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"cc_test":	{
+		"additional_linker_inputs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"args":	{Type: buildpb.Attribute_STRING_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"copts":	{Type: buildpb.Attribute_STRING_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"defines":	{Type: buildpb.Attribute_STRING_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"dynamic_deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"env":	{Type: buildpb.Attribute_STRING_DICT},
+		"env_inherit":	{Type: buildpb.Attribute_STRING_LIST},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"flaky":	{Type: buildpb.Attribute_BOOLEAN},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"includes":	{Type: buildpb.Attribute_STRING_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"linkopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"linkstatic":	{Type: buildpb.Attribute_BOOLEAN},
+		"local":	{Type: buildpb.Attribute_BOOLEAN},
+		"local_defines":	{Type: buildpb.Attribute_STRING_LIST},
+		"malloc":	{Type: buildpb.Attribute_LABEL},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"nocopts":	{Type: buildpb.Attribute_STRING},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"reexport_deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"shard_count":	{Type: buildpb.Attribute_INTEGER},
+		"size":	{Type: buildpb.Attribute_STRING},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"stamp":	{Type: buildpb.Attribute_TRISTATE},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"timeout":	{Type: buildpb.Attribute_STRING},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+		"win_def_file":	{Type: buildpb.Attribute_LABEL},
+	},
+	"cc_toolchain":	{
+		"all_files":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"ar_files":	{Type: buildpb.Attribute_LABEL},
+		"as_files":	{Type: buildpb.Attribute_LABEL},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compiler":	{Type: buildpb.Attribute_STRING},
+		"compiler_files":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"compiler_files_without_includes":	{Type: buildpb.Attribute_LABEL},
+		"coverage_files":	{Type: buildpb.Attribute_LABEL},
+		"cpu":	{Type: buildpb.Attribute_STRING},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"dwp_files":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"dynamic_runtime_lib":	{Type: buildpb.Attribute_LABEL},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"libc_top":	{Type: buildpb.Attribute_LABEL},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"linker_files":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"module_map":	{Type: buildpb.Attribute_LABEL},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"objcopy_files":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"output_licenses":	{Type: buildpb.Attribute_LICENSE},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"static_runtime_lib":	{Type: buildpb.Attribute_LABEL},
+		"strip_files":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"supports_header_parsing":	{Type: buildpb.Attribute_BOOLEAN},
+		"supports_param_files":	{Type: buildpb.Attribute_BOOLEAN},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchain_config":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"toolchain_identifier":	{Type: buildpb.Attribute_STRING},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"cc_toolchain_alias":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"cc_toolchain_suite":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_DICT_UNARY, Mandatory: true},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"config_feature_flag":	{
+		"allowed_values":	{Type: buildpb.Attribute_STRING_LIST, Mandatory: true},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"default_value":	{Type: buildpb.Attribute_STRING},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"config_setting":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"constraint_values":	{Type: buildpb.Attribute_LABEL_LIST},
+		"define_values":	{Type: buildpb.Attribute_STRING_DICT},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"flag_values":	{Type: buildpb.Attribute_LABEL_KEYED_STRING_DICT},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"values":	{Type: buildpb.Attribute_STRING_DICT},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"constraint_setting":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"default_constraint_value":	{Type: buildpb.Attribute_STRING},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"constraint_value":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"constraint_setting":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"environment":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"fulfills":	{Type: buildpb.Attribute_LABEL_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"extra_action":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"cmd":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"out_templates":	{Type: buildpb.Attribute_STRING_LIST},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"requires_action_output":	{Type: buildpb.Attribute_BOOLEAN},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tools":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"fdo_prefetch_hints":	{
+		"absolute_path_profile":	{Type: buildpb.Attribute_STRING},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"profile":	{Type: buildpb.Attribute_LABEL},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"fdo_profile":	{
+		"absolute_path_profile":	{Type: buildpb.Attribute_STRING},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"profile":	{Type: buildpb.Attribute_LABEL},
+		"proto_profile":	{Type: buildpb.Attribute_LABEL},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"filegroup":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"output_group":	{Type: buildpb.Attribute_STRING},
+		"output_licenses":	{Type: buildpb.Attribute_LICENSE},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"path":	{Type: buildpb.Attribute_STRING},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"genquery":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"expression":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"opts":	{Type: buildpb.Attribute_STRING_LIST},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"scope":	{Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"strict":	{Type: buildpb.Attribute_BOOLEAN},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"genrule":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"cmd":	{Type: buildpb.Attribute_STRING},
+		"cmd_bash":	{Type: buildpb.Attribute_STRING},
+		"cmd_bat":	{Type: buildpb.Attribute_STRING},
+		"cmd_ps":	{Type: buildpb.Attribute_STRING},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"exec_tools":	{Type: buildpb.Attribute_LABEL_LIST},
+		"executable":	{Type: buildpb.Attribute_BOOLEAN},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"heuristic_label_expansion":	{Type: buildpb.Attribute_BOOLEAN},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"local":	{Type: buildpb.Attribute_BOOLEAN},
+		"message":	{Type: buildpb.Attribute_STRING},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"output_licenses":	{Type: buildpb.Attribute_LICENSE},
+		"output_to_bindir":	{Type: buildpb.Attribute_BOOLEAN},
+		"outs":	{Type: buildpb.Attribute_OUTPUT_LIST, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"stamp":	{Type: buildpb.Attribute_BOOLEAN},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tools":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"j2objc_library":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"entry_classes":	{Type: buildpb.Attribute_STRING_LIST},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"jre_deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"java_binary":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"args":	{Type: buildpb.Attribute_STRING_LIST},
+		"classpath_resources":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"create_executable":	{Type: buildpb.Attribute_BOOLEAN},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deploy_env":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deploy_manifest_lines":	{Type: buildpb.Attribute_STRING_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"javacopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"jvm_flags":	{Type: buildpb.Attribute_STRING_LIST},
+		"launcher":	{Type: buildpb.Attribute_LABEL},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"main_class":	{Type: buildpb.Attribute_STRING},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"output_licenses":	{Type: buildpb.Attribute_LICENSE},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"plugins":	{Type: buildpb.Attribute_LABEL_LIST},
+		"resource_jars":	{Type: buildpb.Attribute_LABEL_LIST},
+		"resource_strip_prefix":	{Type: buildpb.Attribute_STRING},
+		"resources":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"runtime_deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"stamp":	{Type: buildpb.Attribute_TRISTATE},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"use_testrunner":	{Type: buildpb.Attribute_BOOLEAN},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"java_import":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"constraints":	{Type: buildpb.Attribute_STRING_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"exports":	{Type: buildpb.Attribute_LABEL_LIST},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"jars":	{Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"neverlink":	{Type: buildpb.Attribute_BOOLEAN},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"proguard_specs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"runtime_deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcjar":	{Type: buildpb.Attribute_LABEL},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"java_library":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"exported_plugins":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exports":	{Type: buildpb.Attribute_LABEL_LIST},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"javacopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"neverlink":	{Type: buildpb.Attribute_BOOLEAN},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"plugins":	{Type: buildpb.Attribute_LABEL_LIST},
+		"proguard_specs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"resource_jars":	{Type: buildpb.Attribute_LABEL_LIST},
+		"resource_strip_prefix":	{Type: buildpb.Attribute_STRING},
+		"resources":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"runtime_deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"java_lite_proto_library":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"strict_deps":	{Type: buildpb.Attribute_BOOLEAN},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"java_package_configuration":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"javacopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"output_licenses":	{Type: buildpb.Attribute_LICENSE},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"packages":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"java_plugin":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generates_api":	{Type: buildpb.Attribute_BOOLEAN},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"javacopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"neverlink":	{Type: buildpb.Attribute_BOOLEAN},
+		"output_licenses":	{Type: buildpb.Attribute_LICENSE},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"plugins":	{Type: buildpb.Attribute_LABEL_LIST},
+		"processor_class":	{Type: buildpb.Attribute_STRING},
+		"proguard_specs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"resource_jars":	{Type: buildpb.Attribute_LABEL_LIST},
+		"resource_strip_prefix":	{Type: buildpb.Attribute_STRING},
+		"resources":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"java_proto_library":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"strict_deps":	{Type: buildpb.Attribute_BOOLEAN},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"java_runtime":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"java":	{Type: buildpb.Attribute_LABEL},
+		"java_home":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"output_licenses":	{Type: buildpb.Attribute_LICENSE},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"java_runtime_alias":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"java_test":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"args":	{Type: buildpb.Attribute_STRING_LIST},
+		"classpath_resources":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"create_executable":	{Type: buildpb.Attribute_BOOLEAN},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deploy_manifest_lines":	{Type: buildpb.Attribute_STRING_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"env":	{Type: buildpb.Attribute_STRING_DICT},
+		"env_inherit":	{Type: buildpb.Attribute_STRING_LIST},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"flaky":	{Type: buildpb.Attribute_BOOLEAN},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"javacopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"jvm_flags":	{Type: buildpb.Attribute_STRING_LIST},
+		"launcher":	{Type: buildpb.Attribute_LABEL},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"local":	{Type: buildpb.Attribute_BOOLEAN},
+		"main_class":	{Type: buildpb.Attribute_STRING},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"plugins":	{Type: buildpb.Attribute_LABEL_LIST},
+		"resource_jars":	{Type: buildpb.Attribute_LABEL_LIST},
+		"resource_strip_prefix":	{Type: buildpb.Attribute_STRING},
+		"resources":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"runtime_deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"shard_count":	{Type: buildpb.Attribute_INTEGER},
+		"size":	{Type: buildpb.Attribute_STRING},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"stamp":	{Type: buildpb.Attribute_TRISTATE},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"test_class":	{Type: buildpb.Attribute_STRING},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"timeout":	{Type: buildpb.Attribute_STRING},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"use_testrunner":	{Type: buildpb.Attribute_BOOLEAN},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"java_toolchain":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"bootclasspath":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_javacopts":	{Type: buildpb.Attribute_STRING_LIST_DICT},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"extclasspath":	{Type: buildpb.Attribute_LABEL_LIST},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"forcibly_disable_header_compilation":	{Type: buildpb.Attribute_BOOLEAN},
+		"genclass":	{Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"header_compiler":	{Type: buildpb.Attribute_LABEL_LIST},
+		"header_compiler_builtin_processors":	{Type: buildpb.Attribute_STRING_LIST},
+		"header_compiler_direct":	{Type: buildpb.Attribute_LABEL_LIST},
+		"ijar":	{Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"jacocorunner":	{Type: buildpb.Attribute_LABEL},
+		"javabuilder":	{Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"javabuilder_jvm_opts":	{Type: buildpb.Attribute_STRING_LIST},
+		"javac":	{Type: buildpb.Attribute_LABEL_LIST},
+		"javac_supports_multiplex_workers":	{Type: buildpb.Attribute_BOOLEAN},
+		"javac_supports_workers":	{Type: buildpb.Attribute_BOOLEAN},
+		"javacopts":	{Type: buildpb.Attribute_STRING_LIST},
+		"jvm_opts":	{Type: buildpb.Attribute_STRING_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"misc":	{Type: buildpb.Attribute_STRING_LIST},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"oneversion":	{Type: buildpb.Attribute_LABEL},
+		"oneversion_whitelist":	{Type: buildpb.Attribute_LABEL},
+		"output_licenses":	{Type: buildpb.Attribute_LICENSE},
+		"package_configuration":	{Type: buildpb.Attribute_LABEL_LIST},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"reduced_classpath_incompatible_processors":	{Type: buildpb.Attribute_STRING_LIST},
+		"reduced_classpath_incompatible_targets":	{Type: buildpb.Attribute_STRING_LIST},
+		"resourcejar":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"singlejar":	{Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"source_version":	{Type: buildpb.Attribute_STRING},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"target_version":	{Type: buildpb.Attribute_STRING},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"timezone_data":	{Type: buildpb.Attribute_LABEL},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tools":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"turbine_incompatible_processors":	{Type: buildpb.Attribute_STRING_LIST},
+		"turbine_jvm_opts":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+		"xlint":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"java_toolchain_alias":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"label_flag":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"build_setting_default":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"label_setting":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"build_setting_default":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"local_config_platform":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"repo_mapping":	{Type: buildpb.Attribute_STRING_DICT},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"local_repository":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"path":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"repo_mapping":	{Type: buildpb.Attribute_STRING_DICT},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"new_local_repository":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"build_file":	{Type: buildpb.Attribute_STRING},
+		"build_file_content":	{Type: buildpb.Attribute_STRING},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"path":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"repo_mapping":	{Type: buildpb.Attribute_STRING_DICT},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+		"workspace_file":	{Type: buildpb.Attribute_STRING},
+		"workspace_file_content":	{Type: buildpb.Attribute_STRING},
+	},
+	"ninja_build":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps_mapping":	{Type: buildpb.Attribute_LABEL_DICT_UNARY},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"ninja_graph":	{Type: buildpb.Attribute_LABEL},
+		"output_groups":	{Type: buildpb.Attribute_STRING_LIST_DICT},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"ninja_graph":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"main":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"ninja_srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"output_root":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"output_root_inputs":	{Type: buildpb.Attribute_STRING_LIST},
+		"output_root_symlinks":	{Type: buildpb.Attribute_STRING_LIST},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+		"working_directory":	{Type: buildpb.Attribute_STRING},
+	},
+	"objc_import":	{
+		"alwayslink":	{Type: buildpb.Attribute_BOOLEAN},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"archives":	{Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"hdrs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"includes":	{Type: buildpb.Attribute_STRING_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"sdk_dylibs":	{Type: buildpb.Attribute_STRING_LIST},
+		"sdk_frameworks":	{Type: buildpb.Attribute_STRING_LIST},
+		"sdk_includes":	{Type: buildpb.Attribute_STRING_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"textual_hdrs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+		"weak_sdk_frameworks":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"objc_library":	{
+		"alwayslink":	{Type: buildpb.Attribute_BOOLEAN},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"copts":	{Type: buildpb.Attribute_STRING_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"defines":	{Type: buildpb.Attribute_STRING_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"enable_modules":	{Type: buildpb.Attribute_BOOLEAN},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"hdrs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"includes":	{Type: buildpb.Attribute_STRING_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"module_map":	{Type: buildpb.Attribute_LABEL},
+		"module_name":	{Type: buildpb.Attribute_STRING},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"non_arc_srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"pch":	{Type: buildpb.Attribute_LABEL},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"runtime_deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"sdk_dylibs":	{Type: buildpb.Attribute_STRING_LIST},
+		"sdk_frameworks":	{Type: buildpb.Attribute_STRING_LIST},
+		"sdk_includes":	{Type: buildpb.Attribute_STRING_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"textual_hdrs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+		"weak_sdk_frameworks":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"platform":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"constraint_values":	{Type: buildpb.Attribute_LABEL_LIST},
+		"cpu_constraints":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"host_platform":	{Type: buildpb.Attribute_BOOLEAN},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"os_constraints":	{Type: buildpb.Attribute_LABEL_LIST},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"parents":	{Type: buildpb.Attribute_LABEL_LIST},
+		"remote_execution_properties":	{Type: buildpb.Attribute_STRING},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"target_platform":	{Type: buildpb.Attribute_BOOLEAN},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"proto_lang_toolchain":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"blacklisted_protos":	{Type: buildpb.Attribute_LABEL_LIST},
+		"command_line":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"plugin":	{Type: buildpb.Attribute_LABEL},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"runtime":	{Type: buildpb.Attribute_LABEL},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"proto_library":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"exports":	{Type: buildpb.Attribute_LABEL_LIST},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"import_prefix":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"strip_import_prefix":	{Type: buildpb.Attribute_STRING},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"py_binary":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"args":	{Type: buildpb.Attribute_STRING_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"imports":	{Type: buildpb.Attribute_STRING_LIST},
+		"legacy_create_init":	{Type: buildpb.Attribute_TRISTATE},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"main":	{Type: buildpb.Attribute_LABEL},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"output_licenses":	{Type: buildpb.Attribute_LICENSE},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"python_version":	{Type: buildpb.Attribute_STRING},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"srcs_version":	{Type: buildpb.Attribute_STRING},
+		"stamp":	{Type: buildpb.Attribute_TRISTATE},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"py_library":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"imports":	{Type: buildpb.Attribute_STRING_LIST},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs_version":	{Type: buildpb.Attribute_STRING},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"py_runtime":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"files":	{Type: buildpb.Attribute_LABEL_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"interpreter":	{Type: buildpb.Attribute_LABEL},
+		"interpreter_path":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"output_licenses":	{Type: buildpb.Attribute_LICENSE},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"python_version":	{Type: buildpb.Attribute_STRING},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"py_test":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"args":	{Type: buildpb.Attribute_STRING_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"env":	{Type: buildpb.Attribute_STRING_DICT},
+		"env_inherit":	{Type: buildpb.Attribute_STRING_LIST},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"flaky":	{Type: buildpb.Attribute_BOOLEAN},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"imports":	{Type: buildpb.Attribute_STRING_LIST},
+		"legacy_create_init":	{Type: buildpb.Attribute_TRISTATE},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"local":	{Type: buildpb.Attribute_BOOLEAN},
+		"main":	{Type: buildpb.Attribute_LABEL},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"python_version":	{Type: buildpb.Attribute_STRING},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"shard_count":	{Type: buildpb.Attribute_INTEGER},
+		"size":	{Type: buildpb.Attribute_STRING},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"srcs_version":	{Type: buildpb.Attribute_STRING},
+		"stamp":	{Type: buildpb.Attribute_TRISTATE},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"timeout":	{Type: buildpb.Attribute_STRING},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"sh_binary":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"args":	{Type: buildpb.Attribute_STRING_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"output_licenses":	{Type: buildpb.Attribute_LICENSE},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"sh_library":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"sh_test":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"args":	{Type: buildpb.Attribute_STRING_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"data":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"deps":	{Type: buildpb.Attribute_LABEL_LIST},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"env":	{Type: buildpb.Attribute_STRING_DICT},
+		"env_inherit":	{Type: buildpb.Attribute_STRING_LIST},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"flaky":	{Type: buildpb.Attribute_BOOLEAN},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"local":	{Type: buildpb.Attribute_BOOLEAN},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"shard_count":	{Type: buildpb.Attribute_INTEGER},
+		"size":	{Type: buildpb.Attribute_STRING},
+		"srcs":	{Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"timeout":	{Type: buildpb.Attribute_STRING},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"test_suite":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"tests":	{Type: buildpb.Attribute_LABEL_LIST},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"toolchain":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchain":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"toolchain_type":	{Type: buildpb.Attribute_LABEL, Mandatory: true},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"toolchain_type":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"xcode_config":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"default":	{Type: buildpb.Attribute_LABEL},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"local_versions":	{Type: buildpb.Attribute_LABEL},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"remote_versions":	{Type: buildpb.Attribute_LABEL},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"versions":	{Type: buildpb.Attribute_LABEL_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"xcode_config_alias":	{
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+	"xcode_version":	{
+		"aliases":	{Type: buildpb.Attribute_STRING_LIST},
+		"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+		"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"default_ios_sdk_version":	{Type: buildpb.Attribute_STRING},
+		"default_macos_sdk_version":	{Type: buildpb.Attribute_STRING},
+		"default_tvos_sdk_version":	{Type: buildpb.Attribute_STRING},
+		"default_watchos_sdk_version":	{Type: buildpb.Attribute_STRING},
+		"deprecation":	{Type: buildpb.Attribute_STRING},
+		"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+		"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+		"features":	{Type: buildpb.Attribute_STRING_LIST},
+		"generator_function":	{Type: buildpb.Attribute_STRING},
+		"generator_location":	{Type: buildpb.Attribute_STRING},
+		"generator_name":	{Type: buildpb.Attribute_STRING},
+		"licenses":	{Type: buildpb.Attribute_LICENSE},
+		"name":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+		"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+		"tags":	{Type: buildpb.Attribute_STRING_LIST},
+		"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+		"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+		"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+		"transitive_configs":	{Type: buildpb.Attribute_STRING_LIST},
+		"version":	{Type: buildpb.Attribute_STRING, Mandatory: true},
+		"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+	},
+}
+
+var CommonAttributes = map[string]AttributeSchema{
+	"applicable_licenses":	{Type: buildpb.Attribute_LABEL_LIST},
+	"compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+	"deprecation":	{Type: buildpb.Attribute_STRING},
+	"distribs":	{Type: buildpb.Attribute_DISTRIBUTION_SET},
+	"exec_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+	"exec_properties":	{Type: buildpb.Attribute_STRING_DICT},
+	"features":	{Type: buildpb.Attribute_STRING_LIST},
+	"licenses":	{Type: buildpb.Attribute_LICENSE},
+	"package_metadata":	{Type: buildpb.Attribute_LABEL_LIST},
+	"restricted_to":	{Type: buildpb.Attribute_LABEL_LIST},
+	"tags":	{Type: buildpb.Attribute_STRING_LIST},
+	"target_compatible_with":	{Type: buildpb.Attribute_LABEL_LIST},
+	"testonly":	{Type: buildpb.Attribute_BOOLEAN},
+	"toolchains":	{Type: buildpb.Attribute_LABEL_LIST},
+	"visibility":	{Type: buildpb.Attribute_STRING_LIST},
+}
+
+var CommonTestAttributes = map[string]AttributeSchema{
+	"args":	{Type: buildpb.Attribute_STRING_LIST},
+	"env":	{Type: buildpb.Attribute_STRING_DICT},
+	"env_inherit":	{Type: buildpb.Attribute_STRING_LIST},
+	"flaky":	{Type: buildpb.Attribute_BOOLEAN},
+	"local":	{Type: buildpb.Attribute_BOOLEAN},
+	"shard_count":	{Type: buildpb.Attribute_INTEGER},
+	"size":	{Type: buildpb.Attribute_STRING},
+	"timeout":	{Type: buildpb.Attribute_STRING},
+}
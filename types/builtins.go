@@ -0,0 +1,95 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Types of values returned by builtin functions and of fields of builtin types
+
+package types
+
+import (
+	"strings"
+
+	"github.com/bazelbuild/buildtools/builtins"
+)
+
+// typeNames contains the known types by their names used in the builtins database.
+var typeNames = map[string]Type{
+	"Args":     CtxActionsArgs,
+	"bool":     Bool,
+	"depset":   Depset,
+	"dict":     Dict,
+	"File":     File,
+	"float":    Float,
+	"function": Function,
+	"int":      Int,
+	"Label":    Label,
+	"list":     List,
+	"None":     None,
+	"string":   String,
+	"struct":   Struct,
+	"tuple":    Tuple,
+}
+
+// returnType returns the type of the value returned by a builtin function by its
+// fully qualified name, e.g. "len", "native.glob" or "string.split". The core functions
+// are looked up in the default builtins database, so that their types don't depend on
+// the databases provided by users (which may only describe a few functions), other
+// functions are looked up in the current database.
+func returnType(name string) Type {
+	fn := builtins.Default().Function(name)
+	if fn == nil {
+		fn = builtins.Lookup(name)
+	}
+	if fn == nil {
+		return Unknown
+	}
+	typeName := fn.ReturnType
+	if i := strings.Index(typeName, " of "); i >= 0 {
+		// Parametrized types, e.g. "list of string"
+		typeName = typeName[:i]
+	}
+	return typeNames[typeName]
+}
+
+// fieldTypes contains the types of fields of builtin types.
+var fieldTypes = map[Type]map[string]Type{
+	Ctx: {
+		"actions":           CtxActions,
+		"build_file_path":   String,
+		"disabled_features": List,
+		"features":          List,
+		"label":             Label,
+		"var":               Dict,
+		"workspace_name":    String,
+	},
+	Label: {
+		"name":           String,
+		"package":        String,
+		"repo_name":      String,
+		"workspace_name": String,
+		"workspace_root": String,
+	},
+	File: {
+		"basename":           String,
+		"dirname":            String,
+		"extension":          String,
+		"is_directory":       Bool,
+		"is_source":          Bool,
+		"owner":              Label,
+		"path":               String,
+		"short_path":         String,
+		"tree_relative_path": String,
+	},
+}
@@ -0,0 +1,524 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Type inference for Starlark files
+
+package types
+
+import (
+	"path"
+	"reflect"
+	"regexp"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/bzlenv"
+	"github.com/bazelbuild/buildtools/labels"
+)
+
+// maxPasses limits the number of passes over a file while the types of global variables
+// and function return values are propagated.
+const maxPasses = 10
+
+var intRegexp = regexp.MustCompile(`^([0-9]+|0[Xx][0-9A-Fa-f]+|0[Oo][0-7]+)$`)
+
+// Loader reads a Starlark file given its package and file name, similarly to
+// warn.FileReader.GetFile. It returns nil if the file can't be read.
+type Loader func(pkg, label string) *build.File
+
+// Info contains the inferred types of expressions of a file.
+type Info struct {
+	types   map[build.Expr]Type
+	exports map[string]symbol // top-level symbols that can be loaded by other files
+}
+
+// symbol describes a top-level symbol of a file.
+type symbol struct {
+	typ      Type // type of a variable or return type of a function
+	function bool
+}
+
+// TypeOf returns the inferred type of an expression, or Unknown.
+func (info *Info) TypeOf(expr build.Expr) Type {
+	if info == nil {
+		return Unknown
+	}
+	return info.types[expr]
+}
+
+// Inferrer infers types of expressions and caches the results for loaded files.
+type Inferrer struct {
+	loader Loader
+	files  map[string]*Info // loaded files by their paths, nil while a file is being processed
+}
+
+// NewInferrer creates an Inferrer which reads loaded files with the loader (can be nil).
+func NewInferrer(loader Loader) *Inferrer {
+	return &Inferrer{
+		loader: loader,
+		files:  make(map[string]*Info),
+	}
+}
+
+// Infer infers the types of expressions of a file. Types of symbols loaded from other
+// files of the same repository are resolved using the loader (can be nil).
+func Infer(f *build.File, loader Loader) *Info {
+	return NewInferrer(loader).Infer(f)
+}
+
+// Infer infers the types of expressions of a file. The result for the file itself is
+// not cached because the file can be modified by the caller.
+func (in *Inferrer) Infer(f *build.File) *Info {
+	inf := &inference{
+		inferrer:  in,
+		f:         f,
+		imports:   make(map[*build.Ident]symbol),
+		ctxParams: implementationParams(f),
+		globals:   make(map[int]Type),
+		returns:   make(map[*build.DefStmt]Type),
+	}
+	inf.resolveLoads()
+	inf.run()
+	return &Info{
+		types:   inf.types,
+		exports: inf.exports(),
+	}
+}
+
+// loadFile returns the type information of a file loaded from another file, or nil.
+func (in *Inferrer) loadFile(module string, from *build.File) *Info {
+	if in.loader == nil {
+		return nil
+	}
+	label := labels.ParseRelative(module, from.Pkg)
+	if label.Repository != "" || label.Target == "" {
+		return nil
+	}
+	key := path.Join(label.Package, label.Target)
+	if info, ok := in.files[key]; ok {
+		return info
+	}
+	in.files[key] = nil // guards against cyclic loads
+	f := in.loader(label.Package, label.Target)
+	if f == nil {
+		return nil
+	}
+	info := in.Infer(f)
+	in.files[key] = info
+	return info
+}
+
+// inference contains the state of type inference for a file.
+type inference struct {
+	inferrer  *Inferrer
+	f         *build.File
+	imports   map[*build.Ident]symbol // loaded symbols by their local names in load statements
+	ctxParams map[build.Expr]bool     // first parameters of rule and aspect implementation functions
+
+	// Results of the previous pass
+	globals map[int]Type            // types of global variables at the end of the file
+	returns map[*build.DefStmt]Type // return types of functions
+
+	// State of the current pass
+	types     map[build.Expr]Type
+	variables map[int]Type
+	returned  map[*build.DefStmt][]Type
+	assigned  map[int]Type    // types of global variables
+	exported  map[string]Type // types of global variables by their names
+}
+
+// resolveLoads finds the types of the loaded symbols.
+func (inf *inference) resolveLoads() {
+	for _, stmt := range inf.f.Stmt {
+		load, ok := stmt.(*build.LoadStmt)
+		if !ok {
+			continue
+		}
+		info := inf.inferrer.loadFile(load.Module.Value, inf.f)
+		if info == nil {
+			continue
+		}
+		for i, to := range load.To {
+			if sym, ok := info.exports[load.From[i].Name]; ok {
+				inf.imports[to] = sym
+			}
+		}
+	}
+}
+
+// implementationParams returns the first parameters of functions that are used as
+// implementations of rules and aspects, they are ctx objects regardless of their names.
+func implementationParams(f *build.File) map[build.Expr]bool {
+	functions := make(map[string]*build.DefStmt)
+	for _, stmt := range f.Stmt {
+		if def, ok := stmt.(*build.DefStmt); ok {
+			functions[def.Name] = def
+		}
+	}
+
+	params := make(map[build.Expr]bool)
+	build.Walk(f, func(expr build.Expr, stack []build.Expr) {
+		call, ok := expr.(*build.CallExpr)
+		if !ok {
+			return
+		}
+		if ident, ok := call.X.(*build.Ident); !ok || (ident.Name != "rule" && ident.Name != "aspect") {
+			return
+		}
+		for _, arg := range call.List {
+			as, ok := arg.(*build.AssignExpr)
+			if !ok {
+				continue
+			}
+			if key, ok := as.LHS.(*build.Ident); !ok || key.Name != "implementation" {
+				continue
+			}
+			ident, ok := as.RHS.(*build.Ident)
+			if !ok {
+				continue
+			}
+			if def, ok := functions[ident.Name]; ok && len(def.Params) > 0 {
+				params[def.Params[0]] = true
+			}
+		}
+	})
+	return params
+}
+
+// run walks the file until the types of global variables and return types of functions
+// stop changing, so that they can be used before they are defined.
+func (inf *inference) run() {
+	for pass := 0; pass < maxPasses; pass++ {
+		inf.types = make(map[build.Expr]Type)
+		inf.variables = make(map[int]Type)
+		inf.returned = make(map[*build.DefStmt][]Type)
+		inf.assigned = make(map[int]Type)
+		inf.exported = make(map[string]Type)
+
+		var expr build.Expr = inf.f
+		inf.walk(&expr, bzlenv.NewEnvironment())
+
+		returns := inf.returnTypes()
+		if reflect.DeepEqual(returns, inf.returns) && reflect.DeepEqual(inf.assigned, inf.globals) {
+			return
+		}
+		inf.returns = returns
+		inf.globals = inf.assigned
+	}
+}
+
+// returnTypes calculates the return types of the functions of the file.
+func (inf *inference) returnTypes() map[*build.DefStmt]Type {
+	returns := make(map[*build.DefStmt]Type)
+	for _, stmt := range inf.f.Stmt {
+		def, ok := stmt.(*build.DefStmt)
+		if !ok {
+			continue
+		}
+		types := inf.returned[def]
+		if !endsWithReturn(def.Body) {
+			types = append(types, None)
+		}
+		if len(types) == 0 {
+			// The function always fails
+			continue
+		}
+		t := types[0]
+		for _, other := range types[1:] {
+			if other != t {
+				t = Unknown
+				break
+			}
+		}
+		if t != Unknown {
+			returns[def] = t
+		}
+	}
+	return returns
+}
+
+// endsWithReturn checks whether the control flow can't reach the end of a block of statements.
+func endsWithReturn(stmts []build.Expr) bool {
+	if len(stmts) == 0 {
+		return false
+	}
+	switch stmt := stmts[len(stmts)-1].(type) {
+	case *build.ReturnStmt:
+		return true
+	case *build.IfStmt:
+		return endsWithReturn(stmt.True) && endsWithReturn(stmt.False)
+	case *build.CallExpr:
+		ident, ok := stmt.X.(*build.Ident)
+		return ok && ident.Name == "fail"
+	}
+	return false
+}
+
+// exports returns the top-level symbols of the file that can be loaded by other files.
+func (inf *inference) exports() map[string]symbol {
+	exports := make(map[string]symbol)
+	for _, stmt := range inf.f.Stmt {
+		switch stmt := stmt.(type) {
+		case *build.LoadStmt:
+			for _, to := range stmt.To {
+				if sym, ok := inf.imports[to]; ok {
+					exports[to.Name] = sym
+				} else {
+					delete(exports, to.Name)
+				}
+			}
+		case *build.DefStmt:
+			exports[stmt.Name] = symbol{typ: inf.returns[stmt], function: true}
+		}
+	}
+	for name, t := range inf.exported {
+		exports[name] = symbol{typ: t}
+	}
+	return exports
+}
+
+func (inf *inference) walk(e *build.Expr, env *bzlenv.Environment) {
+	// Postorder: determining types of subnodes may help with this node's type
+	walkOnce(*e, env, inf.walk)
+
+	nodeType := Unknown
+	defer func() {
+		if nodeType != Unknown {
+			inf.types[*e] = nodeType
+		}
+	}()
+
+	switch node := (*e).(type) {
+	case *build.StringExpr:
+		nodeType = String
+	case *build.DictExpr:
+		nodeType = Dict
+	case *build.ListExpr:
+		nodeType = List
+	case *build.TupleExpr:
+		if !node.NoBrackets {
+			nodeType = Tuple
+		}
+	case *build.LiteralExpr:
+		if intRegexp.MatchString(node.Token) {
+			nodeType = Int
+		} else {
+			nodeType = Float
+		}
+	case *build.Comprehension:
+		if node.Curly {
+			nodeType = Dict
+		} else {
+			nodeType = List
+		}
+	case *build.LambdaExpr:
+		nodeType = Function
+	case *build.CallExpr:
+		nodeType = inf.callType(node, env)
+	case *build.ParenExpr:
+		nodeType = inf.types[node.X]
+	case *build.Ident:
+		nodeType = inf.identType(node, env)
+	case *build.DotExpr:
+		nodeType = fieldTypes[inf.types[node.X]][node.Name]
+	case *build.IndexExpr:
+		if inf.types[node.X] == String {
+			nodeType = String
+		}
+	case *build.SliceExpr:
+		switch t := inf.types[node.X]; t {
+		case String, List, Tuple:
+			nodeType = t
+		}
+	case *build.ConditionalExpr:
+		if t := inf.types[node.Then]; t == inf.types[node.Else] {
+			nodeType = t
+		}
+	case *build.UnaryExpr:
+		switch node.Op {
+		case "not":
+			nodeType = Bool
+		case "-", "+":
+			switch t := inf.types[node.X]; t {
+			case Int, Float:
+				nodeType = t
+			}
+		}
+	case *build.BinaryExpr:
+		switch node.Op {
+		case ">", ">=", "<", "<=", "==", "!=", "in", "not in":
+			// Boolean
+			nodeType = Bool
+
+		case "+", "-", "*", "/", "//", "%", "|":
+			// We assume these operators can only applied to expressions of the same type and
+			// preserve the type
+			if t, ok := inf.types[node.X]; ok {
+				nodeType = t
+			} else if t, ok := inf.types[node.Y]; ok {
+				if node.Op != "%" || t == String {
+					// The percent operator is special because it can be applied to to arguments of
+					// different types (`"%s\n" % foo`), and we can't assume that the expression has
+					// type X if the right-hand side has the type X.
+					nodeType = t
+				}
+			}
+		}
+	case *build.ReturnStmt:
+		if env.Function != nil {
+			t := None
+			if node.Result != nil {
+				t = inf.types[node.Result]
+			}
+			inf.returned[env.Function] = append(inf.returned[env.Function], t)
+		}
+	case *build.AssignExpr:
+		inf.assign(node, env)
+	}
+}
+
+// identType returns the type of an identifier.
+func (inf *inference) identType(ident *build.Ident, env *bzlenv.Environment) Type {
+	switch ident.Name {
+	case "True", "False":
+		return Bool
+	case "None":
+		return None
+	}
+	binding := env.Get(ident.Name)
+	if binding == nil {
+		return Unknown
+	}
+	switch binding.Kind {
+	case bzlenv.Parameter:
+		if ident.Name == "ctx" || inf.ctxParams[binding.Definition] {
+			return Ctx
+		}
+	case bzlenv.Function:
+		return Function
+	case bzlenv.Imported:
+		if to, ok := binding.Definition.(*build.Ident); ok {
+			if sym, ok := inf.imports[to]; ok {
+				if sym.function {
+					return Function
+				}
+				return sym.typ
+			}
+		}
+		return Unknown
+	}
+	if t, ok := inf.variables[binding.ID]; ok {
+		return t
+	}
+	if binding.Kind == bzlenv.Global && env.Function != nil {
+		// Functions can use global variables defined after them
+		return inf.globals[binding.ID]
+	}
+	return Unknown
+}
+
+// callType returns the type of the value returned by a function call.
+func (inf *inference) callType(call *build.CallExpr, env *bzlenv.Environment) Type {
+	switch x := call.X.(type) {
+	case *build.Ident:
+		binding := env.Get(x.Name)
+		if binding == nil || binding.Kind == bzlenv.Builtin {
+			return returnType(x.Name)
+		}
+		switch binding.Kind {
+		case bzlenv.Function:
+			if def, ok := binding.Definition.(*build.DefStmt); ok {
+				return inf.returns[def]
+			}
+		case bzlenv.Imported:
+			if to, ok := binding.Definition.(*build.Ident); ok {
+				if sym, ok := inf.imports[to]; ok && sym.function {
+					return sym.typ
+				}
+			}
+		}
+	case *build.DotExpr:
+		if module, ok := x.X.(*build.Ident); ok && env.Get(module.Name) == nil {
+			if t := returnType(module.Name + "." + x.Name); t != Unknown {
+				return t
+			}
+		}
+		if t := inf.types[x.X]; t != Unknown {
+			return returnType(t.String() + "." + x.Name)
+		}
+	}
+	return Unknown
+}
+
+// assign updates the types of variables after an assignment.
+func (inf *inference) assign(as *build.AssignExpr, env *bzlenv.Environment) {
+	ident, ok := as.LHS.(*build.Ident)
+	if !ok {
+		return
+	}
+	binding := env.Get(ident.Name)
+	if binding == nil {
+		return
+	}
+	t, ok := inf.types[as.RHS]
+	if as.Op == "=" {
+		// The identifier on the left hand side has been visited before the assignment and
+		// has got the previous type of the variable, which is not its value anymore
+		delete(inf.types, as.LHS)
+	}
+	if !ok {
+		if as.Op == "=" {
+			// The variable may have had a different type before
+			delete(inf.variables, binding.ID)
+			if binding.Kind == bzlenv.Global && env.Function == nil {
+				delete(inf.assigned, binding.ID)
+				delete(inf.exported, ident.Name)
+			}
+		}
+		return
+	}
+	if as.Op == "%=" && t != String {
+		// If the right hand side is not a string, the left hand side can still be a string
+		return
+	}
+	inf.variables[binding.ID] = t
+	if binding.Kind == bzlenv.Global && env.Function == nil {
+		inf.assigned[binding.ID] = t
+		inf.exported[ident.Name] = t
+	}
+}
+
+// walkOnce is a wrapper for bzlint.WalkOnceWithEnvironment which skips the left hand side for
+// named parameters of functions. E.g. for `foo(x, y = z)` it visits `foo`, `x`, and `z`.
+// In the following example `x` in the last line shouldn't be recognised as int, but 'y' should:
+//
+//    x = 3
+//    y = 5
+//    foo(x = y)
+func walkOnce(node build.Expr, env *bzlenv.Environment, fct func(e *build.Expr, env *bzlenv.Environment)) {
+	switch expr := node.(type) {
+	case *build.CallExpr:
+		fct(&expr.X, env)
+		for _, param := range expr.List {
+			if as, ok := param.(*build.AssignExpr); ok {
+				fct(&as.RHS, env)
+			} else {
+				fct(&param, env)
+			}
+		}
+	default:
+		bzlenv.WalkOnceWithEnvironment(expr, env, fct)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package types infers static types of Starlark expressions.
+package types
+
+// Type describes an expression type in Starlark.
+type Type int
+
+// List of known types
+const (
+	Unknown Type = iota
+	Bool
+	Ctx
+	CtxActions
+	CtxActionsArgs
+	Depset
+	Dict
+	Int
+	None
+	String
+	List
+	Float
+	Tuple
+	Label
+	File
+	Function
+	Struct
+)
+
+func (t Type) String() string {
+	return [...]string{
+		"unknown",
+		"bool",
+		"ctx",
+		"ctx.actions",
+		"ctx.actions.args",
+		"depset",
+		"dict",
+		"int",
+		"none",
+		"string",
+		"list",
+		"float",
+		"tuple",
+		"Label",
+		"File",
+		"function",
+		"struct",
+	}[t]
+}
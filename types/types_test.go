@@ -0,0 +1,504 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/builtins"
+	"github.com/bazelbuild/buildtools/testutils"
+)
+
+func checkTypes(t *testing.T, input, output string) {
+	checkTypesWithFiles(t, nil, input, output)
+}
+
+// checkTypesWithFiles checks the inferred types for a file in the package "pkg" which
+// can load other files by their paths.
+func checkTypesWithFiles(t *testing.T, files map[string]string, input, output string) {
+	input = strings.TrimLeft(input, "\n")
+	f, err := build.Parse("pkg/test.bzl", []byte(input))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	f.Pkg = "pkg"
+	loader := func(pkg, label string) *build.File {
+		filename := pkg + "/" + label
+		content, ok := files[filename]
+		if !ok {
+			return nil
+		}
+		loaded, err := build.ParseBzl(filename, []byte(content))
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		loaded.Pkg = pkg
+		loaded.Label = label
+		return loaded
+	}
+	info := Infer(f, loader)
+
+	var edit func(expr build.Expr, stack []build.Expr) build.Expr
+	edit = func(expr build.Expr, stack []build.Expr) build.Expr {
+		for _, node := range stack {
+			if _, ok := node.(*build.LoadStmt); ok {
+				// Load statements can't contain arbitrary expressions
+				return nil
+			}
+		}
+		t := info.TypeOf(expr)
+		if t == Unknown {
+			return nil
+		}
+		// Traverse the node's children before modifying this node.
+		build.EditChildren(expr, edit)
+		start, _ := expr.Span()
+		return &build.Ident{
+			Name:    fmt.Sprintf("%s:<%s>", t, build.FormatString(expr)),
+			NamePos: start,
+		}
+	}
+
+	build.Edit(f, edit)
+
+	want := []byte(strings.TrimLeft(output, "\n"))
+	have := build.FormatWithoutRewriting(f)
+	if !bytes.Equal(have, want) {
+		t.Errorf("detected types incorrectly: diff shows -expected, +ours")
+		testutils.Tdiff(t, want, have)
+	}
+}
+
+func TestTypes(t *testing.T) {
+	checkTypes(t, `
+s = "string"
+s2 = s
+d = {}
+d2 = {foo: bar}
+d3 = dict(**foo)
+d4 = {k: v for k, v in foo}
+dep = depset(items=[s, d])
+foo = bar
+`, `
+s = string:<"string">
+s2 = string:<s>
+d = dict:<{}>
+d2 = dict:<{foo: bar}>
+d3 = dict:<dict(**foo)>
+d4 = dict:<{k: v for k, v in foo}>
+dep = depset:<depset(items = list:<[
+    string:<s>,
+    dict:<d>,
+]>)>
+foo = bar
+`)
+}
+
+func TestScopesFunction(t *testing.T) {
+	checkTypes(t, `
+s = "string"
+
+def f():
+    s1 = s
+
+def g():
+    s2 = s1
+`, `
+s = string:<"string">
+
+def f():
+    s1 = string:<s>
+
+def g():
+    s2 = s1
+`)
+}
+
+func TestScopesParameters(t *testing.T) {
+	checkTypes(t, `
+x = 3
+y = 4
+z = 5
+
+foo(y = "bar")
+foo(x, y = bar(z = z), t + z)
+
+
+def f(z = "bar"):
+    return z
+
+bar(x, y, z)
+`, `
+x = int:<3>
+y = int:<4>
+z = int:<5>
+
+foo(y = string:<"bar">)
+foo(int:<x>, y = bar(z = int:<z>), int:<t + int:<z>>)
+
+def f(z = string:<"bar">):
+    return string:<z>
+
+bar(int:<x>, int:<y>, int:<z>)
+`)
+}
+
+func TestBinaryOperators(t *testing.T) {
+	checkTypes(t, `
+i = 1
+d = {}
+s = depset()
+
+i - foo
+foo - i
+
+d + bar
+bar + d
+
+s | baz
+baz | s
+`, `
+i = int:<1>
+d = dict:<{}>
+s = depset:<depset()>
+
+int:<int:<i> - foo>
+int:<foo - int:<i>>
+
+dict:<dict:<d> + bar>
+dict:<bar + dict:<d>>
+
+depset:<depset:<s> | baz>
+depset:<baz | depset:<s>>
+`)
+}
+
+func TestPercentOperator(t *testing.T) {
+	checkTypes(t, `
+n = 3
+s = "foo"
+
+foo % n
+foo % s
+foo % bar
+
+n % foo
+s % foo
+
+s %= foo
+n %= foo
+
+baz = unknown
+baz %= s
+baz
+
+boq = unknown
+boq %= n
+boq
+`, `
+n = int:<3>
+s = string:<"foo">
+
+foo % int:<n>
+string:<foo % string:<s>>
+foo % bar
+
+int:<int:<n> % foo>
+string:<string:<s> % foo>
+
+string:<s> %= foo
+int:<n> %= foo
+
+baz = unknown
+baz %= string:<s>
+string:<baz>
+
+boq = unknown
+boq %= int:<n>
+boq
+`)
+}
+
+func TestContext(t *testing.T) {
+	checkTypes(t, `
+def foobar(ctx, foo, bar):
+    ctx
+    ctx.actions
+    ctx.actions.args()
+
+    actions = ctx.actions
+    not_args = actions.args
+    args = actions.args()
+    args
+`, `
+def foobar(ctx:<ctx>, foo, bar):
+    ctx:<ctx>
+    ctx.actions:<ctx:<ctx>.actions>
+    ctx.actions.args:<ctx.actions:<ctx:<ctx>.actions>.args()>
+
+    actions = ctx.actions:<ctx:<ctx>.actions>
+    not_args = ctx.actions:<actions>.args
+    args = ctx.actions.args:<ctx.actions:<actions>.args()>
+    ctx.actions.args:<args>
+`)
+}
+
+func TestContextFalse(t *testing.T) {
+	checkTypes(t, `
+def foobar(foo, bar):
+    ctx
+    ctx.actions
+    ctx.actions.args()
+`, `
+def foobar(foo, bar):
+    ctx
+    ctx.actions
+    ctx.actions.args()
+`)
+}
+
+func TestReturnTypes(t *testing.T) {
+	checkTypes(t, `
+def f():
+    return "foo"
+
+def g(x):
+    if x:
+        return []
+    else:
+        return [x]
+
+def h(x):
+    if x:
+        return 1
+
+def i():
+    pass
+
+def j(x):
+    if x:
+        return 1
+    fail("error")
+
+def k():
+    fail("error")
+
+a = f()
+b = g(1)
+c = h(1)
+d = i()
+e = j(1)
+`, `
+def f():
+    return string:<"foo">
+
+def g(x):
+    if x:
+        return list:<[]>
+    else:
+        return list:<[x]>
+
+def h(x):
+    if x:
+        return int:<1>
+
+def i():
+    pass
+
+def j(x):
+    if x:
+        return int:<1>
+    none:<fail(string:<"error">)>
+
+def k():
+    none:<fail(string:<"error">)>
+
+a = string:<function:<f>()>
+b = list:<function:<g>(int:<1>)>
+c = function:<h>(int:<1>)
+d = none:<function:<i>()>
+e = int:<function:<j>(int:<1>)>
+`)
+}
+
+func TestDefinitionOrder(t *testing.T) {
+	checkTypes(t, `
+def f():
+    x = g()
+    return x + X
+
+def g():
+    return X
+
+X = "x"
+y = f()
+`, `
+def f():
+    x = string:<function:<g>()>
+    return string:<string:<x> + string:<X>>
+
+def g():
+    return string:<X>
+
+X = string:<"x">
+y = string:<function:<f>()>
+`)
+}
+
+func TestReassignment(t *testing.T) {
+	checkTypes(t, `
+s = "foo"
+s = foo()
+s
+`, `
+s = string:<"foo">
+s = foo()
+s
+`)
+}
+
+func TestBuiltins(t *testing.T) {
+	checkTypes(t, `
+s = str(foo)
+n = len(s)
+parts = s.split(".")
+keys = {}.keys()
+l = Label("//foo:bar")
+l.package
+srcs = native.glob(["*.cc"])
+t = (1, 2)
+json.encode(t).strip()
+`, `
+s = string:<str(foo)>
+n = int:<len(string:<s>)>
+parts = list:<string:<s>.split(string:<".">)>
+keys = list:<dict:<{}>.keys()>
+l = Label:<Label(string:<"//foo:bar">)>
+string:<Label:<l>.package>
+srcs = list:<native.glob(list:<[string:<"*.cc">]>)>
+t = tuple:<(int:<1>, int:<2>)>
+string:<string:<json.encode(tuple:<t>)>.strip()>
+`)
+}
+
+func TestRuleImplementation(t *testing.T) {
+	checkTypes(t, `
+def _impl(context):
+    args = context.actions.args().add("foo")
+    out = context.actions.declare_file(context.label.name + ".out")
+    out.short_path
+
+def _other(context):
+    context.actions
+
+foo = rule(implementation = _impl)
+`, `
+def _impl(ctx:<context>):
+    args = ctx.actions.args:<ctx.actions.args:<ctx.actions:<ctx:<context>.actions>.args()>.add(string:<"foo">)>
+    out = File:<ctx.actions:<ctx:<context>.actions>.declare_file(string:<string:<Label:<ctx:<context>.label>.name> + string:<".out">>)>
+    string:<File:<out>.short_path>
+
+def _other(context):
+    context.actions
+
+foo = rule(implementation = function:<_impl>)
+`)
+}
+
+func TestLoadedSymbols(t *testing.T) {
+	files := map[string]string{
+		"pkg/defs.bzl": `
+X = "x"
+
+def f():
+    return {}
+`,
+		"lib/reexport.bzl": `
+load("//pkg:defs.bzl", Y = "X")
+load(":cycle.bzl", "Z")
+`,
+		"lib/cycle.bzl": `
+load(":reexport.bzl", "Y")
+
+Z = Y + "z"
+`,
+	}
+	checkTypesWithFiles(t, files, `
+load(":defs.bzl", "X", "f")
+load("//lib:reexport.bzl", "Y", "Z")
+load("@repo//:defs.bzl", "W")
+
+a = X
+b = f()
+c = Y
+d = Z
+e = W
+`, `
+load(":defs.bzl", "X", "f")
+load("//lib:reexport.bzl", "Y", "Z")
+load("@repo//:defs.bzl", "W")
+
+a = string:<X>
+b = dict:<function:<f>()>
+c = string:<Y>
+d = string:<Z>
+e = W
+`)
+}
+
+func TestBuiltinsReplacedDatabase(t *testing.T) {
+	defer builtins.Reset()
+
+	dir, err := ioutil.TempDir(os.Getenv("TEST_TMPDIR"), "types")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "builtins.textproto")
+	data := `
+function {
+  name: "my_count"
+  return_type: "int"
+}
+`
+	if err := ioutil.WriteFile(file, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := builtins.ParseAndUpdate(file, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// The types of core functions don't depend on the database provided by the user
+	checkTypes(t, `
+s = str(foo)
+n = my_count()
+for c in s:
+    pass
+`, `
+s = string:<str(foo)>
+n = int:<my_count()>
+for c in string:<s>:
+    pass
+`)
+}
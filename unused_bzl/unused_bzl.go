@@ -0,0 +1,216 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The unused_bzl binary reports top-level symbols of .bzl files that are not
+// loaded or used anywhere in the workspace.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/buildifier/utils"
+	"github.com/bazelbuild/buildtools/warn"
+	"github.com/bazelbuild/buildtools/wspace"
+)
+
+var (
+	allowlistFile = flag.String("allowlist", "", "file with label patterns of public API .bzl files whose symbols are not reported, one per line")
+	format        = flag.String("format", "", "diagnostics format: text or json (default text)")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `usage: unused_bzl [-allowlist allowlist.txt] [-format text|json] [path/to/workspace]
+
+If the path to the workspace is omitted, the workspace of the current directory is used.
+
+`)
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+// isStarlarkFile returns whether a file can load or reference symbols of .bzl files.
+func isStarlarkFile(name string) bool {
+	switch name {
+	case "BUILD", "BUILD.bazel", "WORKSPACE", "WORKSPACE.bazel", "WORKSPACE.bzlmod", "MODULE.bazel":
+		return true
+	}
+	return strings.HasSuffix(name, ".bzl")
+}
+
+// readAllowlist reads label patterns from a file, empty lines and comments starting with "#"
+// are ignored.
+func readAllowlist(filename string) ([]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line != "" {
+			patterns = append(patterns, line)
+		}
+	}
+	return patterns, scanner.Err()
+}
+
+// readWorkspace parses all files of the workspace that can load or reference symbols of
+// .bzl files. Directories starting with "." and symlinks (e.g. bazel-out) are skipped.
+// Files that can't be parsed are reported to stderr and their paths are returned separately.
+func readWorkspace(root string) (files []*build.File, invalid []string, err error) {
+	var paths []string
+	packages := make(map[string]bool)
+	err = filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if p != root && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() || !isStarlarkFile(info.Name()) {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		paths = append(paths, rel)
+		if info.Name() == "BUILD" || info.Name() == "BUILD.bazel" {
+			packages[path.Dir(rel)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, rel := range paths {
+		data, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, nil, err
+		}
+		f, err := build.Parse(rel, data)
+		if err != nil {
+			// Parse errors begin with file:line:, don't prefix them
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			invalid = append(invalid, rel)
+			continue
+		}
+		// Files belong to the package of the closest directory with a BUILD file
+		pkg := path.Dir(rel)
+		for pkg != "." && !packages[pkg] {
+			pkg = path.Dir(pkg)
+		}
+		if pkg == "." {
+			pkg = ""
+			f.Label = rel
+		} else {
+			f.Label = strings.TrimPrefix(rel, pkg+"/")
+		}
+		f.Pkg = pkg
+		f.Path = rel
+		files = append(files, f)
+	}
+	return files, invalid, nil
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	var root string
+	switch len(flag.Args()) {
+	case 0:
+		root, _ = wspace.FindWorkspaceRoot("")
+		if root == "" {
+			fmt.Fprintln(os.Stderr, "unused_bzl: not in a workspace, please specify the path to the workspace")
+			os.Exit(2)
+		}
+	case 1:
+		root = flag.Args()[0]
+	default:
+		usage()
+	}
+	if *format != "" && *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "unused_bzl: unrecognized format %s; valid types are text, json\n", *format)
+		os.Exit(2)
+	}
+
+	var allowlist []string
+	if *allowlistFile != "" {
+		var err error
+		if allowlist, err = readAllowlist(*allowlistFile); err != nil {
+			fmt.Fprintf(os.Stderr, "unused_bzl: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	files, invalid, err := readWorkspace(root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unused_bzl: %v\n", err)
+		os.Exit(2)
+	}
+
+	findings := make(map[string][]*warn.Finding)
+	for _, finding := range warn.UnusedBzlSymbols(files, allowlist) {
+		findings[finding.File.Path] = append(findings[finding.File.Path], finding)
+	}
+	isInvalid := make(map[string]bool)
+	for _, filename := range invalid {
+		isInvalid[filename] = true
+	}
+	filenames := append([]string{}, invalid...)
+	for filename := range findings {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	fileDiagnostics := []*utils.FileDiagnostics{}
+	for _, filename := range filenames {
+		if isInvalid[filename] {
+			fileDiagnostics = append(fileDiagnostics, utils.InvalidFileDiagnostics(filename))
+		} else {
+			fileDiagnostics = append(fileDiagnostics, utils.NewFileDiagnostics(filename, findings[filename]))
+		}
+	}
+	diagnostics := utils.NewDiagnostics(fileDiagnostics...)
+	fmt.Print(diagnostics.Format(*format, false))
+	if len(findings) > 0 {
+		os.Exit(4)
+	}
+	if len(invalid) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Repository-wide detection of unused symbols of .bzl files
+
+package warn
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/labels"
+)
+
+// UnusedBzlSymbolCategory is the category of the findings reported by UnusedBzlSymbols.
+const UnusedBzlSymbolCategory = "unused-bzl-symbol"
+
+const unusedBzlSymbolURL = "https://github.com/bazelbuild/buildtools/blob/master/unused_bzl/README.md"
+
+// bzlSymbolKinds describe the values of top-level symbols created by calls to builtin functions.
+var bzlSymbolKinds = map[string]string{
+	"aspect":           "aspect",
+	"macro":            "macro",
+	"module_extension": "module extension",
+	"provider":         "provider",
+	"repository_rule":  "repository rule",
+	"rule":             "rule",
+}
+
+// topLevelSymbol is a symbol defined at the top level of a .bzl file.
+type topLevelSymbol struct {
+	node build.Expr      // the node the symbol is defined by
+	kind string          // e.g. "function" or "rule"
+	refs map[string]bool // the top-level symbols its definition references
+}
+
+// referencedNames returns the names of all identifiers in the expressions.
+func referencedNames(exprs ...build.Expr) map[string]bool {
+	names := make(map[string]bool)
+	for _, expr := range exprs {
+		build.Walk(expr, func(x build.Expr, _ []build.Expr) {
+			if ident, ok := x.(*build.Ident); ok {
+				names[ident.Name] = true
+			}
+		})
+	}
+	return names
+}
+
+// symbolKind returns a description of the value assigned to a top-level symbol.
+func symbolKind(rhs build.Expr) string {
+	switch rhs := rhs.(type) {
+	case *build.CallExpr:
+		if ident, ok := rhs.X.(*build.Ident); ok {
+			if kind, ok := bzlSymbolKinds[ident.Name]; ok {
+				return kind
+			}
+		}
+	case *build.StringExpr, *build.LiteralExpr, *build.ListExpr, *build.DictExpr, *build.TupleExpr:
+		return "constant"
+	}
+	return "symbol"
+}
+
+// topLevelSymbols returns the symbols defined at the top level of a .bzl file and the
+// symbols referenced by the other top-level statements.
+func topLevelSymbols(f *build.File) (map[string]*topLevelSymbol, map[string]bool) {
+	symbols := make(map[string]*topLevelSymbol)
+	roots := make(map[string]bool)
+	define := func(name string, node build.Expr, kind string, refs map[string]bool) {
+		if symbol, ok := symbols[name]; ok {
+			// Redefinitions and augmented assignments, keep the first definition
+			for name := range refs {
+				symbol.refs[name] = true
+			}
+			return
+		}
+		symbols[name] = &topLevelSymbol{node, kind, refs}
+	}
+	for _, stmt := range f.Stmt {
+		switch stmt := stmt.(type) {
+		case *build.LoadStmt, *build.CommentBlock:
+		case *build.DefStmt:
+			refs := referencedNames(stmt.Body...)
+			for _, param := range stmt.Params {
+				for name := range referencedNames(param) {
+					refs[name] = true
+				}
+			}
+			define(stmt.Name, stmt, "function", refs)
+		case *build.AssignExpr:
+			lhs, ok := stmt.LHS.(*build.Ident)
+			if !ok {
+				// Tuple assignments, all symbols are considered used
+				for name := range referencedNames(stmt) {
+					roots[name] = true
+				}
+				continue
+			}
+			define(lhs.Name, lhs, symbolKind(stmt.RHS), referencedNames(stmt.RHS))
+		default:
+			for name := range referencedNames(stmt) {
+				roots[name] = true
+			}
+		}
+	}
+	return symbols, roots
+}
+
+// bzlSymbolRef is a symbol of a .bzl file identified by the path of the file relative to
+// the workspace root.
+type bzlSymbolRef struct {
+	path, name string
+}
+
+// labelPath returns the path of the file a label refers to, or false if it's not a file
+// of the main repository.
+func labelPath(value, pkg string) (string, bool) {
+	label := labels.ParseRelative(value, pkg)
+	if label.Repository != "" || label.Target == "" {
+		return "", false
+	}
+	return path.Join(label.Package, label.Target), true
+}
+
+// externalRefs returns the symbols of .bzl files that are used by a file: loaded symbols,
+// module extensions and repository rules used in MODULE.bazel files, and symbols referenced
+// by strings like "//foo:bar.bzl%baz" (e.g. aspects or transitions used on the command line).
+func externalRefs(f *build.File) []bzlSymbolRef {
+	var refs []bzlSymbolRef
+	for _, stmt := range f.Stmt {
+		if load, ok := stmt.(*build.LoadStmt); ok {
+			if p, ok := labelPath(load.Module.Value, f.Pkg); ok {
+				for _, from := range load.From {
+					refs = append(refs, bzlSymbolRef{p, from.Name})
+				}
+			}
+		}
+	}
+	build.Walk(f, func(x build.Expr, _ []build.Expr) {
+		switch x := x.(type) {
+		case *build.StringExpr:
+			// The references can be parts of command line flags, e.g. "--aspects=//foo:bar.bzl%baz"
+			for _, token := range strings.FieldsFunc(x.Value, func(r rune) bool { return r == ' ' || r == '=' || r == ',' }) {
+				if i := strings.LastIndex(token, "%"); i > 0 && strings.HasSuffix(token[:i], ".bzl") {
+					if p, ok := labelPath(token[:i], f.Pkg); ok {
+						refs = append(refs, bzlSymbolRef{p, token[i+1:]})
+					}
+				}
+			}
+		case *build.CallExpr:
+			ident, ok := x.X.(*build.Ident)
+			if !ok || (ident.Name != "use_extension" && ident.Name != "use_repo_rule") || len(x.List) < 2 {
+				return
+			}
+			module, ok1 := x.List[0].(*build.StringExpr)
+			name, ok2 := x.List[1].(*build.StringExpr)
+			if !ok1 || !ok2 {
+				return
+			}
+			if p, ok := labelPath(module.Value, f.Pkg); ok {
+				refs = append(refs, bzlSymbolRef{p, name.Value})
+			}
+		}
+	})
+	return refs
+}
+
+// UnusedBzlSymbols analyzes all BUILD, .bzl, WORKSPACE and MODULE.bazel files of a repository
+// and reports the symbols defined at the top level of .bzl files that are neither loaded by
+// other files nor used by code that is. The `Pkg` and `Label` fields of the files should be set,
+// so that their paths relative to the workspace root are `Pkg/Label`. All symbols of the .bzl
+// files that match the label patterns of the allowlist (see LabelPolicy) are considered used,
+// e.g. the public API of rulesets.
+func UnusedBzlSymbols(files []*build.File, allowlist []string) []*Finding {
+	used := make(map[bzlSymbolRef]bool)
+	for _, f := range files {
+		for _, ref := range externalRefs(f) {
+			used[ref] = true
+		}
+	}
+
+	var findings []*Finding
+	for _, f := range files {
+		if f.Type != build.TypeBzl {
+			continue
+		}
+		p := path.Join(f.Pkg, f.Label)
+		label := labels.Label{Package: f.Pkg, Target: f.Label}
+		if matchAny(allowlist, func(pattern string) bool { return matchLabelPattern(pattern, label) }) {
+			continue
+		}
+
+		// Symbols are used if they're used by other files or (transitively) by used symbols
+		symbols, roots := topLevelSymbols(f)
+		var queue []string
+		for name := range symbols {
+			if used[bzlSymbolRef{p, name}] {
+				queue = append(queue, name)
+			}
+		}
+		for name := range roots {
+			queue = append(queue, name)
+		}
+		reached := make(map[string]bool)
+		for len(queue) > 0 {
+			name := queue[0]
+			queue = queue[1:]
+			symbol, ok := symbols[name]
+			if !ok || reached[name] {
+				continue
+			}
+			reached[name] = true
+			for ref := range symbol.refs {
+				queue = append(queue, ref)
+			}
+		}
+
+		var names []string
+		for name := range symbols {
+			// Private symbols that are not used in the file are reported by the "unused-variable" warning
+			if !reached[name] && !strings.HasPrefix(name, "_") {
+				names = append(names, name)
+			}
+		}
+		sort.Slice(names, func(i, j int) bool {
+			start1, _ := symbols[names[i]].node.Span()
+			start2, _ := symbols[names[j]].node.Span()
+			return start1.Byte < start2.Byte
+		})
+		suppressions := newSuppressionIndex(f)
+		for _, name := range names {
+			symbol := symbols[name]
+			start, end := symbol.node.Span()
+			if len(suppressions.disablingComments(start.Line, UnusedBzlSymbolCategory)) > 0 {
+				continue
+			}
+			message := fmt.Sprintf("The %s %q is not loaded by any other file and is not used by the code that is.", symbol.kind, name)
+			findings = append(findings, makeFinding(f, start, end, UnusedBzlSymbolCategory, unusedBzlSymbolURL, message, true, nil))
+		}
+	}
+	return findings
+}
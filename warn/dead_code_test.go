@@ -0,0 +1,141 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warn
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/bazelbuild/buildtools/build"
+)
+
+func TestUnusedBzlSymbols(t *testing.T) {
+	sources := map[string]string{
+		"BUILD": `
+load("//lib:defs.bzl", "my_rule", "used_macro")
+
+my_rule(name = "a")
+`,
+		"MODULE.bazel": `
+ext = use_extension("//lib:extensions.bzl", "my_ext")
+`,
+		"lib/BUILD": ``,
+		"lib/defs.bzl": `
+load(":helpers.bzl", "helper")
+
+MY_CONSTANT = ["a", "b"]
+
+UNUSED_CONSTANT = {"a": 1}
+
+MyInfo = provider()
+
+def _impl(ctx):
+    return [MyInfo(values = MY_CONSTANT)]
+
+my_rule = rule(implementation = _impl)
+
+unused_rule = rule(implementation = _impl)
+
+def used_macro(name):
+    helper(name)
+
+def unused_macro(name):
+    dead_helper(name)
+
+def dead_helper(name):
+    pass
+
+# buildifier: disable=unused-bzl-symbol
+def suppressed(name):
+    pass
+
+alias = used_macro
+
+my_aspect = aspect(implementation = _impl)
+`,
+		"lib/helpers.bzl": `
+def helper(name):
+    pass
+
+def unused_helper():
+    pass
+`,
+		"lib/extensions.bzl": `
+my_ext = module_extension(implementation = lambda ctx: None)
+
+other_ext = module_extension(implementation = lambda ctx: None)
+`,
+		"lib/aspects.bzl": `
+def _impl(target, ctx):
+    return []
+
+cli_aspect = aspect(implementation = _impl)
+`,
+		"tools/BUILD": `
+sh_binary(
+    name = "run",
+    srcs = ["run.sh"],
+    args = ["--aspects=//lib:aspects.bzl%cli_aspect"],
+)
+`,
+		"api/defs.bzl": `
+def public_api():
+    pass
+`,
+	}
+
+	var files []*build.File
+	for filename, content := range sources {
+		f, err := build.Parse(filename, []byte(content))
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", filename, err)
+		}
+		f.Pkg = path.Dir(filename)
+		if f.Pkg == "." {
+			f.Pkg = ""
+		}
+		f.Label = path.Base(filename)
+		files = append(files, f)
+	}
+
+	var got []string
+	for _, finding := range UnusedBzlSymbols(files, []string{"//api/..."}) {
+		got = append(got, fmt.Sprintf("%s/%s:%d: %s", finding.File.Pkg, finding.File.Label, finding.Start.Line, finding.Message))
+		if finding.Category != UnusedBzlSymbolCategory {
+			t.Errorf("unexpected category %q", finding.Category)
+		}
+	}
+	want := []string{
+		`lib/defs.bzl:6: The constant "UNUSED_CONSTANT" is not loaded by any other file and is not used by the code that is.`,
+		`lib/defs.bzl:15: The rule "unused_rule" is not loaded by any other file and is not used by the code that is.`,
+		`lib/defs.bzl:20: The function "unused_macro" is not loaded by any other file and is not used by the code that is.`,
+		`lib/defs.bzl:23: The function "dead_helper" is not loaded by any other file and is not used by the code that is.`,
+		`lib/defs.bzl:30: The symbol "alias" is not loaded by any other file and is not used by the code that is.`,
+		`lib/defs.bzl:32: The aspect "my_aspect" is not loaded by any other file and is not used by the code that is.`,
+		`lib/extensions.bzl:4: The module extension "other_ext" is not loaded by any other file and is not used by the code that is.`,
+		`lib/helpers.bzl:5: The function "unused_helper" is not loaded by any other file and is not used by the code that is.`,
+	}
+	// The order of the files is not deterministic
+	sort.Strings(got)
+	sort.Strings(want)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("UnusedBzlSymbols:\ngot:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
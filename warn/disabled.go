@@ -0,0 +1,245 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Lookup of comments that disable warnings
+
+package warn
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+)
+
+// directiveRegexp matches comments that disable warnings for a whole file, the next line,
+// or a region between `disable-start` and `disable-end` comments.
+var directiveRegexp = regexp.MustCompile(`(?i)\b(?:buildifier|buildozer): (disable-file|disable-next-line|disable-start|disable-end)=([^\s,]+)`)
+
+// suppression describes a range of lines where a warning is disabled by comments.
+type suppression struct {
+	start, end int // the range of lines, inclusive
+	// category is the disabled warning category. If it's empty, the suppression is
+	// a `disable=` comment attached to a node, and token should be checked instead.
+	category string
+	token    string
+	comments []build.Comment // the comments that define the suppression
+}
+
+// matches checks if the suppression disables the warning on the line.
+func (s *suppression) matches(line int, warning string) bool {
+	if line < s.start || line > s.end {
+		return false
+	}
+	if s.category == "" {
+		return isDisablingComment(s.token, warning)
+	}
+	return s.category == strings.ToLower(warning)
+}
+
+// suppressionIndex contains all suppressions of a file.
+type suppressionIndex struct {
+	suppressions []suppression
+	categories   map[string]*intervalIndex // lazily built indices for warning categories
+}
+
+// intervalIndex splits the lines of a file into segments, so that all lines of a segment
+// are covered by the same suppressions.
+type intervalIndex struct {
+	bounds   []int   // sorted first lines of the segments
+	covering [][]int // indices of the suppressions covering each segment
+}
+
+// directive is a comment with a `disable-file`, `disable-next-line`, `disable-start` or `disable-end` directive.
+type directive struct {
+	kind, category string
+	comment        build.Comment
+}
+
+// newSuppressionIndex collects the suppressions of a file.
+func newSuppressionIndex(f *build.File) *suppressionIndex {
+	index := &suppressionIndex{}
+	var directives []directive
+
+	build.Walk(f, func(expr build.Expr, stack []build.Expr) {
+		if expr == nil {
+			return
+		}
+
+		start, end := expr.Span()
+		comments := expr.Comment()
+		if len(comments.Before) > 0 {
+			start, _ = comments.Before[0].Span()
+		}
+		if len(comments.After) > 0 {
+			_, end = comments.After[len(comments.After)-1].Span()
+		}
+
+		for _, list := range [][]build.Comment{comments.Before, comments.Suffix, comments.After} {
+			for _, c := range list {
+				token := strings.ToLower(c.Token)
+				if strings.Contains(token, "buildifier: disable=") || strings.Contains(token, "buildozer: disable=") {
+					index.suppressions = append(index.suppressions, suppression{
+						start:    start.Line,
+						end:      end.Line,
+						token:    token,
+						comments: []build.Comment{c},
+					})
+				}
+				for _, m := range directiveRegexp.FindAllStringSubmatch(c.Token, -1) {
+					directives = append(directives, directive{strings.ToLower(m[1]), strings.ToLower(m[2]), c})
+				}
+			}
+		}
+	})
+
+	// `disable-file` comments should be located before the first statement
+	firstLine := math.MaxInt32
+	for _, stmt := range f.Stmt {
+		if stmt == nil {
+			// Statements removed by fixes
+			continue
+		}
+		if _, ok := stmt.(*build.CommentBlock); !ok {
+			start, _ := stmt.Span()
+			firstLine = start.Line
+			break
+		}
+	}
+
+	sort.SliceStable(directives, func(i, j int) bool {
+		return directives[i].comment.Start.Line < directives[j].comment.Start.Line
+	})
+	regions := make(map[string]int) // categories of open regions -> indices of their suppressions
+	for _, d := range directives {
+		line := d.comment.Start.Line
+		switch d.kind {
+		case "disable-file":
+			if line < firstLine {
+				index.add(1, math.MaxInt32, d.category, d.comment)
+			}
+		case "disable-next-line":
+			index.add(line+1, line+1, d.category, d.comment)
+		case "disable-start":
+			if _, ok := regions[d.category]; !ok {
+				regions[d.category] = len(index.suppressions)
+				index.add(line, math.MaxInt32, d.category, d.comment)
+			}
+		case "disable-end":
+			if i, ok := regions[d.category]; ok {
+				s := &index.suppressions[i]
+				s.end = line
+				s.comments = append(s.comments, d.comment)
+				delete(regions, d.category)
+			}
+		}
+	}
+	return index
+}
+
+func (index *suppressionIndex) add(start, end int, category string, comment build.Comment) {
+	index.suppressions = append(index.suppressions, suppression{
+		start:    start,
+		end:      end,
+		category: category,
+		comments: []build.Comment{comment},
+	})
+}
+
+// newIntervalIndex creates an interval index for the suppressions of a warning category.
+func (index *suppressionIndex) newIntervalIndex(warning string) *intervalIndex {
+	var matching []int
+	boundSet := make(map[int]bool)
+	for i, s := range index.suppressions {
+		if (s.category == "" && isDisablingComment(s.token, warning)) || s.category == strings.ToLower(warning) {
+			matching = append(matching, i)
+			boundSet[s.start] = true
+			if s.end < math.MaxInt32 {
+				boundSet[s.end+1] = true
+			}
+		}
+	}
+
+	intervals := &intervalIndex{}
+	for bound := range boundSet {
+		intervals.bounds = append(intervals.bounds, bound)
+	}
+	sort.Ints(intervals.bounds)
+	for _, bound := range intervals.bounds {
+		var covering []int
+		for _, i := range matching {
+			if index.suppressions[i].start <= bound && bound <= index.suppressions[i].end {
+				covering = append(covering, i)
+			}
+		}
+		intervals.covering = append(intervals.covering, covering)
+	}
+	return intervals
+}
+
+// disablingComments returns the comments that disable the warning on the line.
+func (index *suppressionIndex) disablingComments(line int, warning string) []build.Comment {
+	if index.categories == nil {
+		index.categories = make(map[string]*intervalIndex)
+	}
+	intervals, ok := index.categories[warning]
+	if !ok {
+		intervals = index.newIntervalIndex(warning)
+		index.categories[warning] = intervals
+	}
+
+	// The last segment that starts before or on the line
+	segment := sort.SearchInts(intervals.bounds, line+1) - 1
+	if segment < 0 {
+		return nil
+	}
+	var result []build.Comment
+	for _, i := range intervals.covering[segment] {
+		result = append(result, index.suppressions[i].comments...)
+	}
+	return result
+}
+
+// fileSuppressions provides the lookup of suppressions for all warnings run on a file by
+// FileWarnings and collects the comments that have suppressed findings.
+type fileSuppressions struct {
+	f     *build.File
+	index *suppressionIndex // built on demand, reset when the file is modified
+	used  map[build.Position]bool
+}
+
+func newFileSuppressions(f *build.File) *fileSuppressions {
+	return &fileSuppressions{f: f, used: make(map[build.Position]bool)}
+}
+
+// disabled checks whether the warning is disabled on the line and marks the disabling comments as used.
+func (s *fileSuppressions) disabled(line int, warning string) bool {
+	if s.index == nil {
+		s.index = newSuppressionIndex(s.f)
+	}
+	comments := s.index.disablingComments(line, warning)
+	for _, c := range comments {
+		s.used[c.Start] = true
+	}
+	return len(comments) > 0
+}
+
+// invalidate should be called after the file is modified.
+func (s *fileSuppressions) invalidate() {
+	s.index = nil
+}
@@ -24,13 +24,14 @@ import (
 
 	"github.com/bazelbuild/buildtools/testutils"
 	"github.com/bazelbuild/buildtools/warn"
+	"github.com/bazelbuild/buildtools/warn/docs/generator"
 )
 
 func TestAllWarningsAreDocumented(t *testing.T) {
 	testdata := path.Join(os.Getenv("TEST_SRCDIR"), os.Getenv("TEST_WORKSPACE"))
 
 	textprotoPath := path.Join(testdata, "warn", "docs", "warnings.textproto")
-	warnings, err := readWarningsFromFile(textprotoPath)
+	warnings, err := generator.ReadWarningsFromFile(textprotoPath)
 	if err != nil {
 		t.Fatalf("getWarnings(%q) = %v", textprotoPath, err)
 	}
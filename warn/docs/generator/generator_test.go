@@ -0,0 +1,60 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/bazelbuild/buildtools/warn/plugins"
+
+	docspb "github.com/bazelbuild/buildtools/warn/docs/proto"
+)
+
+func TestPluginWarningsAreDocumented(t *testing.T) {
+	dir, err := ioutil.TempDir(os.Getenv("TEST_TMPDIR"), "docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	plugin := path.Join(dir, "plugin.star")
+	content := `
+def _check(ctx):
+    return []
+
+lint(name = "docs-test-plugin", check = _check, doc = "Header of the warning\n\nDescription of the warning.", autofix = True)
+`
+	if err := ioutil.WriteFile(plugin, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := plugins.LoadPlugins([]string{plugin}); err != nil {
+		t.Fatalf("LoadPlugins(%q) = %v", plugin, err)
+	}
+
+	warnings := &docspb.Warnings{}
+	AddRegisteredWarnings(warnings)
+	if len(warnings.Warnings) != 1 {
+		t.Fatalf("AddRegisteredWarnings() added %d warnings, want 1", len(warnings.Warnings))
+	}
+	w := warnings.Warnings[0]
+	if w.Name[0] != "docs-test-plugin" || w.Header != "Header of the warning" || w.Description != "Description of the warning." || !w.Autofix {
+		t.Errorf("AddRegisteredWarnings() = %v, want the documentation of the plugin", w)
+	}
+}
@@ -17,14 +17,26 @@ limitations under the License.
 package warn
 
 import (
+	"errors"
+	"os"
+
 	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/labels"
+	"github.com/bazelbuild/buildtools/types"
 )
 
 // FileReader is a class that can read an arbitrary Starlark file
 // from the repository and cache the results.
 type FileReader struct {
-	cache    map[string]*build.File
-	readFile func(string) ([]byte, error)
+	cache       map[string]*build.File
+	readFile    func(string) ([]byte, error)
+	statFile    func(string) (os.FileInfo, error)
+	repoMapping *labels.RepoMapping // repository mapping of the main repository, nil if unknown
+	inferrer    *types.Inferrer
+	typeInfos   map[*build.File]*types.Info // inferred types of the analyzed files
+	schemas     map[string]*fileSchemas     // rule schemas of loaded files
+	packages    map[string]*packageTargets  // targets declared in BUILD files by their packages
+	exists      map[string]bool             // whether files exist in the repository
 }
 
 // NewFileReader creates and initializes a FileReader instance with a
@@ -38,6 +50,30 @@ func NewFileReader(readFile func(string) ([]byte, error)) *FileReader {
 	}
 }
 
+// SetStatFile sets a custom statFile function that returns information about an arbitrary file
+// in the repository (similarly to os.Stat) using a path relative to the workspace root. It's used
+// to check whether files exist without reading them, otherwise the files are read with readFile.
+func (fr *FileReader) SetStatFile(statFile func(string) (os.FileInfo, error)) {
+	fr.statFile = statFile
+}
+
+// SetRepoMapping sets the repository mapping of the main repository (e.g. parsed from its
+// MODULE.bazel file). It's used to recognize labels that refer to the main repository by its
+// apparent name, and labels that refer to other repositories by their canonical names.
+func (fr *FileReader) SetRepoMapping(mapping *labels.RepoMapping) {
+	fr.repoMapping = mapping
+}
+
+// resolveLabel parses a label relative to `pkg` and converts it to the canonical form
+// using the repository mapping, see labels.RepoMapping.Resolve. Can be called on a nil
+// FileReader, then the label is only parsed.
+func (fr *FileReader) resolveLabel(label, pkg string) labels.Label {
+	if fr == nil {
+		return labels.ParseRelative(label, pkg)
+	}
+	return fr.repoMapping.Resolve(labels.ParseRelative(label, pkg))
+}
+
 // retrieveFile reads a Starlark file using only the readFile method
 // (without using the cache).
 func (fr *FileReader) retrieveFile(filename string) *build.File {
@@ -75,3 +111,37 @@ func (fr *FileReader) GetFile(pkg, label string) *build.File {
 	fr.cache[filename] = file
 	return file
 }
+
+// fileExists checks whether a file or a directory exists in the repository, the results are
+// cached. If the FileReader has no statFile function, the file is read instead, and files that
+// exist but can't be read (e.g. directories) are considered existing.
+func (fr *FileReader) fileExists(filename string) bool {
+	if fr.exists == nil {
+		fr.exists = make(map[string]bool)
+	}
+	if exists, ok := fr.exists[filename]; ok {
+		return exists
+	}
+	var exists bool
+	if fr.statFile != nil {
+		_, err := fr.statFile(filename)
+		exists = err == nil
+	} else {
+		_, err := fr.readFile(filename)
+		exists = err == nil || !errors.Is(err, os.ErrNotExist)
+	}
+	fr.exists[filename] = exists
+	return exists
+}
+
+// typeInferrer returns a type inferrer that caches the types of the loaded files.
+// Can be called on a nil FileReader, then loaded symbols are not resolved.
+func (fr *FileReader) typeInferrer() *types.Inferrer {
+	if fr == nil {
+		return types.NewInferrer(nil)
+	}
+	if fr.inferrer == nil {
+		fr.inferrer = types.NewInferrer(fr.GetFile)
+	}
+	return fr.inferrer
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugins loads custom lint warnings written in Starlark.
+//
+// A plugin is a Starlark file that defines warning categories by calling the
+// `lint` builtin at the top level:
+//
+//	def _check(ctx):
+//	    return [
+//	        finding(rule, "java_test rules should set the size.")
+//	        for rule in ctx.rules
+//	        if rule.kind == "java_test" and "size" not in rule.attrs
+//	    ]
+//
+//	lint(name = "java-test-size", check = _check)
+//
+// The check function receives a read-only view of a file and returns a list
+// of findings created by the `finding` builtin. A finding may contain
+// replacement text for the node it's reported for, which is used by the
+// linter in the fix mode.
+package plugins
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/warn"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// lintDefinition is a warning category defined by a plugin.
+type lintDefinition struct {
+	name  string
+	check starlark.Callable
+	opts  warn.WarningOptions
+}
+
+// finding is a Starlark value returned by the `finding` builtin.
+type finding struct {
+	node        *node
+	message     string
+	replacement build.Expr
+	hasFix      bool
+}
+
+var _ starlark.Value = (*finding)(nil)
+
+func (f *finding) String() string        { return fmt.Sprintf("<finding %q>", f.message) }
+func (f *finding) Type() string          { return "finding" }
+func (f *finding) Freeze()               {}
+func (f *finding) Truth() starlark.Bool  { return starlark.True }
+func (f *finding) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: finding") }
+
+// parseReplacement parses the replacement text of a finding. Empty text means
+// that the node should be removed.
+func parseReplacement(n *node, text string) (build.Expr, error) {
+	if strings.TrimSpace(text) == "" {
+		if n.typ != "rule" && n.typ != "call" && n.typ != "attr" && n.typ != "load" {
+			return nil, fmt.Errorf("a node of type %q can't be removed", n.typ)
+		}
+		return nil, nil
+	}
+	f, err := build.ParseDefault("replacement", []byte(text))
+	if err != nil {
+		return nil, fmt.Errorf("can't parse the replacement: %v", err)
+	}
+	if len(f.Stmt) != 1 {
+		return nil, fmt.Errorf("the replacement should be a single expression, got %q", text)
+	}
+	return f.Stmt[0], nil
+}
+
+func findingBuiltin(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var n *node
+	var message string
+	var replacement starlark.Value = starlark.None
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "node", &n, "message", &message, "replacement?", &replacement); err != nil {
+		return nil, err
+	}
+	result := &finding{node: n, message: message}
+	switch replacement := replacement.(type) {
+	case starlark.NoneType:
+	case starlark.String:
+		expr, err := parseReplacement(n, string(replacement))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", b.Name(), err)
+		}
+		result.replacement = expr
+		result.hasFix = true
+	default:
+		return nil, fmt.Errorf("%s: replacement should be a string or None, got %s", b.Name(), replacement.Type())
+	}
+	return result, nil
+}
+
+// newThread creates a Starlark thread for executing a plugin file.
+func newThread(filename string) *starlark.Thread {
+	return &starlark.Thread{
+		Name: filename,
+		Print: func(_ *starlark.Thread, msg string) {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", filename, msg)
+		},
+		Load: func(_ *starlark.Thread, module string) (starlark.StringDict, error) {
+			return nil, fmt.Errorf("load() is not supported in lint plugins")
+		},
+	}
+}
+
+// runCheck runs a check function of a plugin on a file and converts the results to linter findings.
+func runCheck(filename string, lint lintDefinition, f *build.File) []*warn.LinterFinding {
+	// Replacements are applied to the original syntax tree, the plugin only sees its read-only view.
+	result, err := starlark.Call(newThread(filename), lint.check, starlark.Tuple{fileContext(f)}, nil)
+	if err != nil {
+		return []*warn.LinterFinding{pluginFailure(filename, lint.name, err)}
+	}
+
+	iter := starlark.Iterate(result)
+	if iter == nil {
+		return []*warn.LinterFinding{pluginFailure(filename, lint.name,
+			fmt.Errorf("the check function should return a list of findings, got %s", result.Type()))}
+	}
+	defer iter.Done()
+
+	var findings []*warn.LinterFinding
+	var x starlark.Value
+	for iter.Next(&x) {
+		fnd, ok := x.(*finding)
+		if !ok {
+			return []*warn.LinterFinding{pluginFailure(filename, lint.name,
+				fmt.Errorf("the check function should return a list of findings, got an element of type %s", x.Type()))}
+		}
+		start, end := fnd.node.expr.Span()
+		lf := &warn.LinterFinding{
+			Start:   start,
+			End:     end,
+			Message: fnd.message,
+		}
+		if fnd.hasFix && fnd.node.slot != nil {
+			lf.Replacement = []warn.LinterReplacement{{Old: fnd.node.slot, New: fnd.replacement}}
+		}
+		findings = append(findings, lf)
+	}
+	return findings
+}
+
+// pluginFailure creates a finding reporting that a plugin has failed on a file.
+func pluginFailure(filename, name string, err error) *warn.LinterFinding {
+	return &warn.LinterFinding{
+		Start:   build.Position{Line: 1, LineRune: 1},
+		End:     build.Position{Line: 1, LineRune: 1},
+		Message: fmt.Sprintf("Lint plugin %q from %s failed: %v", name, filename, err),
+	}
+}
+
+// LoadPlugin executes a Starlark plugin file and registers the warning categories it defines.
+// Returns the names of the registered categories.
+func LoadPlugin(filename string) ([]string, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var lints []lintDefinition
+	lintBuiltin := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var name, doc, url string
+		var check starlark.Callable
+		enabled := true
+		autofix := false
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs,
+			"name", &name, "check", &check, "doc?", &doc, "url?", &url,
+			"default?", &enabled, "autofix?", &autofix); err != nil {
+			return nil, err
+		}
+		if name == "" {
+			return nil, fmt.Errorf("%s: name can't be empty", b.Name())
+		}
+		header, description := doc, ""
+		if i := strings.Index(doc, "\n"); i >= 0 {
+			header, description = doc[:i], strings.TrimSpace(doc[i+1:])
+		}
+		lints = append(lints, lintDefinition{
+			name:  name,
+			check: check,
+			opts: warn.WarningOptions{
+				URL:               url,
+				DisabledByDefault: !enabled,
+				Header:            header,
+				Description:       description,
+				Autofix:           autofix,
+			},
+		})
+		return starlark.None, nil
+	}
+
+	predeclared := starlark.StringDict{
+		"lint":    starlark.NewBuiltin("lint", lintBuiltin),
+		"finding": starlark.NewBuiltin("finding", findingBuiltin),
+		"struct":  starlark.NewBuiltin("struct", starlarkstruct.Make),
+	}
+	if _, err := starlark.ExecFile(newThread(filename), filename, data, predeclared); err != nil {
+		if evalErr, ok := err.(*starlark.EvalError); ok {
+			return nil, fmt.Errorf("%s", evalErr.Backtrace())
+		}
+		return nil, err
+	}
+
+	existing := make(map[string]bool)
+	for _, name := range warn.AllWarnings {
+		existing[name] = true
+	}
+	for _, lint := range lints {
+		if existing[lint.name] {
+			return nil, fmt.Errorf("%s: warning category %q is already defined", filename, lint.name)
+		}
+		existing[lint.name] = true
+	}
+	var names []string
+	for _, lint := range lints {
+		lint := lint
+		warn.RegisterFileWarning(lint.name, func(f *build.File) []*warn.LinterFinding {
+			return runCheck(filename, lint, f)
+		}, lint.opts)
+		names = append(names, lint.name)
+	}
+	return names, nil
+}
+
+// LoadPlugins loads multiple plugin files, see LoadPlugin.
+func LoadPlugins(filenames []string) ([]string, error) {
+	var names []string
+	for _, filename := range filenames {
+		loaded, err := LoadPlugin(filename)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, loaded...)
+	}
+	return names, nil
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/warn"
+)
+
+// loadTestPlugin writes a plugin file to a temporary directory and loads it.
+// The registered warnings are removed when the test finishes.
+func loadTestPlugin(t *testing.T, content string) []string {
+	dir, err := ioutil.TempDir(os.Getenv("TEST_TMPDIR"), "plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "plugin.star")
+	if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	names, err := LoadPlugin(filename)
+	if err != nil {
+		t.Fatalf("LoadPlugin() returned an error: %v", err)
+	}
+	t.Cleanup(func() {
+		for _, name := range names {
+			warn.UnregisterWarning(name)
+		}
+	})
+	return names
+}
+
+// lintFile runs a warning on a BUILD file and returns the findings and the fixed content.
+func lintFile(t *testing.T, category, input string) ([]string, string) {
+	parse := func() *build.File {
+		f, err := build.ParseBuild("test/package/BUILD", []byte(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Pkg = "test/package"
+		return f
+	}
+
+	var findings []string
+	for _, fnd := range warn.FileWarnings(parse(), []string{category}, nil, warn.ModeWarn, nil) {
+		findings = append(findings, fmt.Sprintf(":%d: %s", fnd.Start.Line, fnd.Message))
+	}
+	f := parse()
+	warn.FixWarnings(f, []string{category}, false, nil)
+	return findings, string(build.Format(f))
+}
+
+func compare(t *testing.T, got, want []string) {
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got findings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestPluginRules(t *testing.T) {
+	names := loadTestPlugin(t, `
+def _check_size(ctx):
+    findings = []
+    for rule in ctx.rules:
+        if rule.kind != "java_test":
+            continue
+        if "size" not in rule.attrs:
+            findings.append(finding(rule, "%s in %s should set the size." % (rule.name, ctx.pkg)))
+        elif rule.attrs["size"].value == "enormous":
+            findings.append(finding(rule.attrs["size"], "Size shouldn't be enormous.", 'size = "large"'))
+    return findings
+
+def _check_banned(ctx):
+    return [
+        finding(call, "Banned call at %d:%d." % (call.start.line, call.start.column), "")
+        for call in ctx.calls
+        if call.kind == "banned"
+    ]
+
+lint(name = "plugin-test-size", check = _check_size, doc = "Tests should set the size", autofix = True)
+lint(name = "plugin-test-banned", check = _check_banned, default = False)
+`)
+	compare(t, names, []string{"plugin-test-size", "plugin-test-banned"})
+
+	if opts := warn.RegisteredWarnings()["plugin-test-size"]; opts.Header != "Tests should set the size" || !opts.Autofix {
+		t.Errorf("unexpected options of plugin-test-size: %+v", opts)
+	}
+	for _, name := range warn.DefaultWarnings {
+		if name == "plugin-test-banned" {
+			t.Errorf("plugin-test-banned shouldn't be enabled by default")
+		}
+	}
+
+	findings, fixed := lintFile(t, "plugin-test-size", `java_test(
+    name = "a",
+)
+
+java_test(
+    name = "b",
+    size = "enormous",
+)
+`)
+	compare(t, findings, []string{
+		":1: a in test/package should set the size.",
+		":7: Size shouldn't be enormous.",
+	})
+	if want := `java_test(
+    name = "a",
+)
+
+java_test(
+    name = "b",
+    size = "large",
+)
+`; fixed != want {
+		t.Errorf("fixed file:\n%s\nwant:\n%s", fixed, want)
+	}
+
+	findings, fixed = lintFile(t, "plugin-test-banned", `foo(name = "a")
+
+banned(name = "b")
+
+foo(
+    name = "c",
+    deps = [banned()],
+)
+`)
+	compare(t, findings, []string{
+		":3: Banned call at 3:1.",
+		":7: Banned call at 7:13.",
+	})
+	if want := `foo(name = "a")
+
+foo(
+    name = "c",
+    deps = [],
+)
+`; fixed != want {
+		t.Errorf("fixed file:\n%s\nwant:\n%s", fixed, want)
+	}
+}
+
+func TestPluginLoadsAndValues(t *testing.T) {
+	loadTestPlugin(t, `
+def _check(ctx):
+    findings = []
+    for l in ctx.loads:
+        if l.module.startswith("//old"):
+            findings.append(finding(l, "Symbols %s are loaded from an old file." % sorted(l.symbols.keys())))
+    for rule in ctx.rules:
+        for attr in rule.attrs.values():
+            if attr.value == None:
+                findings.append(finding(attr.expr, "%s.%s isn't a literal: %s." % (rule.name, attr.name, attr.expr.text)))
+        if rule.attrs.get("srcs") and len(rule.attrs["srcs"].value) > 2:
+            findings.append(finding(rule.attrs["srcs"].expr, "Too many srcs.", '["a.cc"]'))
+    return findings
+
+lint(name = "plugin-test-values", check = _check)
+`)
+
+	findings, fixed := lintFile(t, "plugin-test-values", `load("//old:defs.bzl", "a", b = "c")
+
+cc_library(
+    name = "x",
+    srcs = ["a.cc", "b.cc", "c.cc"],
+    copts = COPTS,
+    linkstatic = True,
+)
+`)
+	compare(t, findings, []string{
+		`:1: Symbols ["a", "b"] are loaded from an old file.`,
+		`:5: Too many srcs.`,
+		`:6: x.copts isn't a literal: COPTS.`,
+	})
+	if !strings.Contains(fixed, `srcs = ["a.cc"],`) {
+		t.Errorf("srcs weren't replaced:\n%s", fixed)
+	}
+}
+
+func TestPluginFailure(t *testing.T) {
+	loadTestPlugin(t, `
+def _check(ctx):
+    ctx.rules[0].attrs["name"] = None
+    return []
+
+def _check_result(ctx):
+    return 42
+
+lint(name = "plugin-test-mutation", check = _check)
+lint(name = "plugin-test-result", check = _check_result)
+`)
+
+	findings, _ := lintFile(t, "plugin-test-mutation", `foo(name = "a")`)
+	if len(findings) != 1 || !strings.Contains(findings[0], `Lint plugin "plugin-test-mutation"`) ||
+		!strings.Contains(findings[0], "frozen") {
+		t.Errorf("unexpected findings: %q", findings)
+	}
+
+	findings, _ = lintFile(t, "plugin-test-result", `foo(name = "a")`)
+	if len(findings) != 1 || !strings.Contains(findings[0], "should return a list of findings") {
+		t.Errorf("unexpected findings: %q", findings)
+	}
+}
+
+func TestLoadPluginErrors(t *testing.T) {
+	dir, err := ioutil.TempDir(os.Getenv("TEST_TMPDIR"), "plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, tc := range []struct {
+		content string
+		err     string
+	}{
+		{`load(":foo.star", "x")`, "load() is not supported"},
+		{`lint(name = "load", check = lambda ctx: [])`, `"load" is already defined`},
+		{`lint(name = "", check = lambda ctx: [])`, "name can't be empty"},
+		{`finding(None, "x", 42)`, "finding"},
+		{`lint(name = "x"`, "got end of file"},
+	} {
+		filename := filepath.Join(dir, "plugin.star")
+		if err := ioutil.WriteFile(filename, []byte(tc.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadPlugin(filename); err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("LoadPlugin(%q) returned error %v, want %q", tc.content, err, tc.err)
+		}
+	}
+}
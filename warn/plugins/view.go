@@ -0,0 +1,211 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Read-only view of the syntax tree of a file for Starlark lint plugins
+
+package plugins
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/bazelbuild/buildtools/build"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// node is a Starlark value representing a node of the syntax tree of a file.
+type node struct {
+	typ   string      // "rule", "call", "attr", "load" or "expr"
+	expr  build.Expr  // the syntax tree node
+	slot  *build.Expr // the place where the node is stored in the syntax tree, used for replacements
+	attrs starlark.StringDict
+}
+
+var _ starlark.HasAttrs = (*node)(nil)
+
+func (n *node) String() string        { return fmt.Sprintf("<%s %s>", n.typ, build.FormatString(n.expr)) }
+func (n *node) Type() string          { return "node" }
+func (n *node) Freeze()               {}
+func (n *node) Truth() starlark.Bool  { return starlark.True }
+func (n *node) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: node") }
+
+func (n *node) Attr(name string) (starlark.Value, error) {
+	if v, ok := n.attrs[name]; ok {
+		return v, nil
+	}
+	return nil, nil
+}
+
+func (n *node) AttrNames() []string {
+	var names []string
+	for name := range n.attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newNode creates a node with the attributes common for all node types.
+func newNode(typ string, expr build.Expr, slot *build.Expr) *node {
+	start, end := expr.Span()
+	return &node{
+		typ:  typ,
+		expr: expr,
+		slot: slot,
+		attrs: starlark.StringDict{
+			"type":  starlark.String(typ),
+			"start": position(start),
+			"end":   position(end),
+			"text":  starlark.String(build.FormatString(expr)),
+		},
+	}
+}
+
+// position converts a position in a file to a Starlark struct.
+func position(pos build.Position) starlark.Value {
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"line":   starlark.MakeInt(pos.Line),
+		"column": starlark.MakeInt(pos.LineRune),
+	})
+}
+
+// value converts a literal expression to a Starlark value. Returns None
+// if the expression is not a literal.
+func value(expr build.Expr) starlark.Value {
+	switch expr := expr.(type) {
+	case *build.StringExpr:
+		return starlark.String(expr.Value)
+	case *build.LiteralExpr:
+		if i, err := strconv.ParseInt(expr.Token, 0, 64); err == nil {
+			return starlark.MakeInt64(i)
+		}
+	case *build.Ident:
+		switch expr.Name {
+		case "True":
+			return starlark.True
+		case "False":
+			return starlark.False
+		}
+	case *build.ListExpr:
+		return values(expr.List)
+	case *build.TupleExpr:
+		return values(expr.List)
+	case *build.DictExpr:
+		dict := starlark.NewDict(len(expr.List))
+		for _, kv := range expr.List {
+			if err := dict.SetKey(value(kv.Key), value(kv.Value)); err != nil {
+				return starlark.None
+			}
+		}
+		dict.Freeze()
+		return dict
+	}
+	return starlark.None
+}
+
+func values(list []build.Expr) starlark.Tuple {
+	var result starlark.Tuple
+	for _, x := range list {
+		result = append(result, value(x))
+	}
+	return result
+}
+
+// exprNode creates a node for an arbitrary expression.
+func exprNode(expr build.Expr, slot *build.Expr) *node {
+	n := newNode("expr", expr, slot)
+	n.attrs["value"] = value(expr)
+	return n
+}
+
+// callNode creates a node for a function call. Top-level calls are represented as rules.
+func callNode(typ string, call *build.CallExpr, slot *build.Expr) *node {
+	n := newNode(typ, call, slot)
+	rule := build.NewRule(call)
+
+	var name starlark.Value = starlark.None
+	if as := rule.AttrDefn("name"); as != nil {
+		name = value(as.RHS)
+	}
+	attrs := starlark.NewDict(len(call.List))
+	var args starlark.Tuple
+	for i := range call.List {
+		switch arg := call.List[i].(type) {
+		case *build.AssignExpr:
+			lhs, ok := arg.LHS.(*build.Ident)
+			if !ok {
+				continue
+			}
+			attr := newNode("attr", arg, &call.List[i])
+			attr.attrs["name"] = starlark.String(lhs.Name)
+			attr.attrs["value"] = value(arg.RHS)
+			attr.attrs["expr"] = exprNode(arg.RHS, &arg.RHS)
+			attrs.SetKey(starlark.String(lhs.Name), attr)
+		default:
+			args = append(args, exprNode(arg, &call.List[i]))
+		}
+	}
+	attrs.Freeze()
+
+	n.attrs["kind"] = starlark.String(build.FormatString(call.X))
+	n.attrs["name"] = name
+	n.attrs["attrs"] = attrs
+	n.attrs["args"] = args
+	return n
+}
+
+// loadNode creates a node for a load statement.
+func loadNode(load *build.LoadStmt, slot *build.Expr) *node {
+	n := newNode("load", load, slot)
+	symbols := starlark.NewDict(len(load.To))
+	for i := range load.To {
+		symbols.SetKey(starlark.String(load.To[i].Name), starlark.String(load.From[i].Name))
+	}
+	symbols.Freeze()
+	n.attrs["module"] = starlark.String(load.Module.Value)
+	n.attrs["symbols"] = symbols
+	return n
+}
+
+// fileContext creates a read-only view of a file that's passed to the lint plugins.
+func fileContext(f *build.File) starlark.Value {
+	var rules, calls, loads starlark.Tuple
+	for i := range f.Stmt {
+		switch stmt := f.Stmt[i].(type) {
+		case *build.CallExpr:
+			rules = append(rules, callNode("rule", stmt, &f.Stmt[i]))
+		case *build.LoadStmt:
+			loads = append(loads, loadNode(stmt, &f.Stmt[i]))
+		}
+	}
+	build.WalkPointers(f, func(x *build.Expr, stk []build.Expr) {
+		if call, ok := (*x).(*build.CallExpr); ok {
+			calls = append(calls, callNode("call", call, x))
+		}
+	})
+
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"path":  starlark.String(f.Path),
+		"pkg":   starlark.String(f.Pkg),
+		"label": starlark.String(f.Label),
+		"type":  starlark.String(f.Type.String()),
+		"rules": rules,
+		"calls": calls,
+		"loads": loads,
+	})
+}
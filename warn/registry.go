@@ -0,0 +1,117 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Registration of custom warning categories
+
+package warn
+
+import (
+	"fmt"
+
+	"github.com/bazelbuild/buildtools/build"
+)
+
+// WarningOptions contains the settings of a custom warning category.
+type WarningOptions struct {
+	// URL of the documentation of the warning. If empty, a link to WARNINGS.md is used.
+	URL string
+	// DisabledByDefault means that the warning is not included in DefaultWarnings
+	// and should be enabled explicitly.
+	DisabledByDefault bool
+
+	// Header, Description and Autofix are used by the documentation generator
+	// for the warnings registered by lint plugins (see its --lint_plugins flag).
+	Header      string
+	Description string
+	Autofix     bool
+}
+
+// registeredWarnings contains the options of custom warning categories.
+var registeredWarnings = make(map[string]WarningOptions)
+
+// RegisterFileWarning adds a custom warning category that runs on the whole file.
+// Autofixes are provided by the warning function as replacements in the returned findings.
+// Warnings should be registered before the linter is used, e.g. from an `init` function
+// of the binary that embeds the linter. Panics if a warning with the same name already exists.
+func RegisterFileWarning(name string, fct func(f *build.File) []*LinterFinding, opts WarningOptions) {
+	checkNewWarning(name)
+	FileWarningMap[name] = fct
+	registerWarning(name, opts)
+}
+
+// RegisterMultiFileWarning adds a custom warning category that runs on the whole file
+// and may read other files using a FileReader, see RegisterFileWarning.
+func RegisterMultiFileWarning(name string, fct func(f *build.File, fileReader *FileReader) []*LinterFinding, opts WarningOptions) {
+	checkNewWarning(name)
+	MultiFileWarningMap[name] = fct
+	registerWarning(name, opts)
+}
+
+// RegisterRuleWarning adds a custom warning category that runs on every rule call
+// in BUILD files, see RegisterFileWarning.
+func RegisterRuleWarning(name string, fct func(call *build.CallExpr, pkg string) *LinterFinding, opts WarningOptions) {
+	checkNewWarning(name)
+	RuleWarningMap[name] = fct
+	registerWarning(name, opts)
+}
+
+// RegisteredWarnings returns the options of all custom warning categories by their names.
+func RegisteredWarnings() map[string]WarningOptions {
+	result := make(map[string]WarningOptions)
+	for name, opts := range registeredWarnings {
+		result[name] = opts
+	}
+	return result
+}
+
+// UnregisterWarning removes a custom warning category added by one of the Register functions,
+// e.g. to reload lint plugins. Built-in warnings can't be removed.
+func UnregisterWarning(name string) {
+	if _, ok := registeredWarnings[name]; !ok {
+		return
+	}
+	delete(FileWarningMap, name)
+	delete(MultiFileWarningMap, name)
+	delete(RuleWarningMap, name)
+	delete(nonDefaultWarnings, name)
+	delete(registeredWarnings, name)
+	AllWarnings = collectAllWarnings()
+	DefaultWarnings = collectDefaultWarnings()
+}
+
+// checkNewWarning panics if the name of a new warning category is empty or already used.
+func checkNewWarning(name string) {
+	if name == "" {
+		panic("warning category name can't be empty")
+	}
+	_, isFileWarning := FileWarningMap[name]
+	_, isMultiFileWarning := MultiFileWarningMap[name]
+	_, isRuleWarning := RuleWarningMap[name]
+	if isFileWarning || isMultiFileWarning || isRuleWarning || name == unusedSuppressionCategory {
+		panic(fmt.Sprintf("warning category %q is already registered", name))
+	}
+}
+
+// registerWarning stores the options of a new warning category and updates
+// the lists of all and default warnings.
+func registerWarning(name string, opts WarningOptions) {
+	registeredWarnings[name] = opts
+	if opts.DisabledByDefault {
+		nonDefaultWarnings[name] = true
+	}
+	AllWarnings = collectAllWarnings()
+	DefaultWarnings = collectDefaultWarnings()
+}
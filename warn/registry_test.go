@@ -0,0 +1,152 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warn
+
+import (
+	"testing"
+
+	"github.com/bazelbuild/buildtools/build"
+)
+
+// unregisterWarnings removes custom warnings registered by a test.
+func unregisterWarnings(names ...string) {
+	for _, name := range names {
+		UnregisterWarning(name)
+	}
+}
+
+func contains(list []string, item string) bool {
+	for _, x := range list {
+		if x == item {
+			return true
+		}
+	}
+	return false
+}
+
+// bannedRuleWarning is an example of a custom warning: it reports and removes `banned_rule` calls.
+func bannedRuleWarning(f *build.File) []*LinterFinding {
+	var findings []*LinterFinding
+	for i, stmt := range f.Stmt {
+		if call, ok := stmt.(*build.CallExpr); ok && build.NewRule(call).Kind() == "banned_rule" {
+			findings = append(findings, makeLinterFinding(call, "banned_rule is not allowed.",
+				LinterReplacement{&f.Stmt[i], nil}))
+		}
+	}
+	return findings
+}
+
+func TestRegisterFileWarning(t *testing.T) {
+	defer unregisterWarnings("banned-rule", "banned-rule-off")
+
+	RegisterFileWarning("banned-rule", bannedRuleWarning, WarningOptions{
+		URL:         "https://example.com/banned-rule",
+		Header:      "Banned rules",
+		Description: "banned_rule shouldn't be used.",
+		Autofix:     true,
+	})
+	RegisterFileWarning("banned-rule-off", bannedRuleWarning, WarningOptions{DisabledByDefault: true})
+
+	if !contains(AllWarnings, "banned-rule") || !contains(AllWarnings, "banned-rule-off") {
+		t.Errorf("AllWarnings = %v, want it to contain registered warnings", AllWarnings)
+	}
+	if !contains(DefaultWarnings, "banned-rule") {
+		t.Errorf("DefaultWarnings = %v, want it to contain %q", DefaultWarnings, "banned-rule")
+	}
+	if contains(DefaultWarnings, "banned-rule-off") {
+		t.Errorf("DefaultWarnings = %v, don't want it to contain %q", DefaultWarnings, "banned-rule-off")
+	}
+	if opts := RegisteredWarnings()["banned-rule"]; opts.Header != "Banned rules" {
+		t.Errorf("RegisteredWarnings()[%q].Header = %q, want %q", "banned-rule", opts.Header, "Banned rules")
+	}
+
+	checkFindingsAndFix(t, "banned-rule", `
+foo()
+banned_rule(name = "x")`, `
+foo()`,
+		[]string{":2: banned_rule is not allowed."},
+		scopeEverywhere)
+
+	f, err := build.ParseBuild("BUILD", []byte(`banned_rule(name = "x")`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	findings := FileWarnings(f, []string{"banned-rule", "banned-rule-off"}, nil, ModeWarn, nil)
+	if len(findings) != 2 {
+		t.Fatalf("FileWarnings() returned %d findings, want 2", len(findings))
+	}
+	for _, finding := range findings {
+		want := docURL(finding.Category)
+		if finding.Category == "banned-rule" {
+			want = "https://example.com/banned-rule"
+		}
+		if finding.URL != want {
+			t.Errorf("finding %q has URL %q, want %q", finding.Category, finding.URL, want)
+		}
+	}
+}
+
+func TestRegisterRuleWarning(t *testing.T) {
+	defer unregisterWarnings("required-tags")
+
+	RegisterRuleWarning("required-tags", func(call *build.CallExpr, pkg string) *LinterFinding {
+		if build.NewRule(call).Attr("tags") == nil {
+			return makeLinterFinding(call, "All rules should have tags.")
+		}
+		return nil
+	}, WarningOptions{})
+
+	checkFindings(t, "required-tags", `
+foo(name = "a", tags = ["x"])
+foo(name = "b")`,
+		[]string{":2: All rules should have tags."},
+		scopeBuild)
+}
+
+func TestRegisterExistingWarning(t *testing.T) {
+	for _, name := range []string{"", "load", "deprecated-function", "positional-args"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterFileWarning(%q) didn't panic", name)
+				}
+			}()
+			RegisterFileWarning(name, bannedRuleWarning, WarningOptions{})
+		}()
+	}
+}
+
+func TestUnregisterWarning(t *testing.T) {
+	RegisterFileWarning("banned-rule", bannedRuleWarning, WarningOptions{DisabledByDefault: true})
+	UnregisterWarning("banned-rule")
+	if contains(AllWarnings, "banned-rule") {
+		t.Errorf("AllWarnings = %v, don't want it to contain %q", AllWarnings, "banned-rule")
+	}
+	if _, ok := RegisteredWarnings()["banned-rule"]; ok {
+		t.Errorf("RegisteredWarnings() contains %q after it was unregistered", "banned-rule")
+	}
+
+	// The warning can be registered again
+	RegisterFileWarning("banned-rule", bannedRuleWarning, WarningOptions{})
+	UnregisterWarning("banned-rule")
+
+	// Built-in warnings can't be removed
+	UnregisterWarning("print")
+	if !contains(AllWarnings, "print") {
+		t.Errorf("AllWarnings = %v, want it to contain %q", AllWarnings, "print")
+	}
+}
@@ -0,0 +1,452 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Extraction of attribute schemas of Starlark rules and macros
+
+package warn
+
+import (
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/lang"
+
+	buildpb "github.com/bazelbuild/buildtools/build_proto"
+)
+
+// attrFunctionTypes maps functions of the `attr` module to the types of attributes they declare.
+var attrFunctionTypes = map[string]buildpb.Attribute_Discriminator{
+	"bool":                    buildpb.Attribute_BOOLEAN,
+	"int":                     buildpb.Attribute_INTEGER,
+	"int_list":                buildpb.Attribute_INTEGER_LIST,
+	"label":                   buildpb.Attribute_LABEL,
+	"label_keyed_string_dict": buildpb.Attribute_LABEL_KEYED_STRING_DICT,
+	"label_list":              buildpb.Attribute_LABEL_LIST,
+	"license":                 buildpb.Attribute_LICENSE,
+	"output":                  buildpb.Attribute_OUTPUT,
+	"output_list":             buildpb.Attribute_OUTPUT_LIST,
+	"string":                  buildpb.Attribute_STRING,
+	"string_dict":             buildpb.Attribute_STRING_DICT,
+	"string_keyed_label_dict": buildpb.Attribute_LABEL_DICT_UNARY,
+	"string_list":             buildpb.Attribute_STRING_LIST,
+	"string_list_dict":        buildpb.Attribute_STRING_LIST_DICT,
+}
+
+// RuleSchemas returns the attribute schemas of the rules and macros defined in the file or
+// loaded by it, indexed by their names in the file. Rules are declared with `rule()` or
+// `macro()`, macros are functions with a `name` parameter whose parameters are considered
+// their attributes. Rules and macros whose attributes can't be determined statically are
+// omitted.
+//
+// The loaded files are read with the fileReader, if it's nil only the rules and macros
+// defined in the file itself are returned.
+func RuleSchemas(f *build.File, fileReader *FileReader) map[string]map[string]lang.AttributeSchema {
+	return analyzeSchemas(f, fileReader).schemas
+}
+
+// fileSchemas contains the attribute schemas of the rules and macros available in a file.
+type fileSchemas struct {
+	schemas map[string]map[string]lang.AttributeSchema // schemas of rules and macros by their names
+	macros  map[string]bool                            // names of the macros defined as functions
+}
+
+// analyzeSchemas returns the schemas of the rules and macros defined in the file or loaded by it.
+func analyzeSchemas(f *build.File, fileReader *FileReader) *fileSchemas {
+	sa := schemaAnalyzer{
+		fileReader: fileReader,
+		fileSchemas: fileSchemas{
+			schemas: make(map[string]map[string]lang.AttributeSchema),
+			macros:  make(map[string]bool),
+		},
+		attrDicts: make(map[string]map[string]lang.AttributeSchema),
+	}
+	sa.analyze(f)
+	return &sa.fileSchemas
+}
+
+// loadedSchemas returns the schemas of the rules and macros defined in a loaded file,
+// or nil if the file can't be read.
+func (fr *FileReader) loadedSchemas(module string, from *build.File) *fileSchemas {
+	if fr == nil {
+		return nil
+	}
+	label := fr.resolveLabel(module, from.Pkg)
+	if label.Repository != "" || label.Target == "" {
+		return nil
+	}
+	key := label.Package + ":" + label.Target
+	if fr.schemas == nil {
+		fr.schemas = make(map[string]*fileSchemas)
+	}
+	if schemas, ok := fr.schemas[key]; ok {
+		return schemas
+	}
+	fr.schemas[key] = nil // guards against cyclic loads
+	f := fr.GetFile(label.Package, label.Target)
+	if f == nil {
+		return nil
+	}
+	schemas := analyzeSchemas(f, fr)
+	fr.schemas[key] = schemas
+	return schemas
+}
+
+// schemaAnalyzer contains the state of schema extraction for a file.
+type schemaAnalyzer struct {
+	fileSchemas
+	fileReader *FileReader
+	attrDicts  map[string]map[string]lang.AttributeSchema // global variables that contain attribute dicts
+}
+
+func (sa *schemaAnalyzer) analyze(f *build.File) {
+	var defs []*build.DefStmt
+	for _, stmt := range f.Stmt {
+		switch stmt := stmt.(type) {
+		case *build.LoadStmt:
+			loaded := sa.fileReader.loadedSchemas(stmt.Module.Value, f)
+			if loaded == nil {
+				continue
+			}
+			for i, from := range stmt.From {
+				if schema, ok := loaded.schemas[from.Name]; ok {
+					sa.schemas[stmt.To[i].Name] = schema
+					sa.macros[stmt.To[i].Name] = loaded.macros[from.Name]
+				}
+			}
+		case *build.AssignExpr:
+			lhs, ok := stmt.LHS.(*build.Ident)
+			if !ok || stmt.Op != "=" {
+				continue
+			}
+			// The name can be reused for a different value
+			delete(sa.schemas, lhs.Name)
+			delete(sa.macros, lhs.Name)
+			delete(sa.attrDicts, lhs.Name)
+
+			if rhs, ok := stmt.RHS.(*build.Ident); ok {
+				// An alias, e.g. `my_rule = _my_rule`
+				if schema, ok := sa.schemas[rhs.Name]; ok {
+					sa.schemas[lhs.Name] = schema
+					sa.macros[lhs.Name] = sa.macros[rhs.Name]
+					continue
+				}
+			}
+			if schema, ok := sa.ruleSchema(stmt.RHS); ok {
+				sa.schemas[lhs.Name] = schema
+			} else if attrs, ok := sa.attrDict(stmt.RHS); ok {
+				sa.attrDicts[lhs.Name] = attrs
+			}
+		case *build.DefStmt:
+			defs = append(defs, stmt)
+		}
+	}
+
+	// Macros may call rules and other macros defined later in the file, analyze them
+	// until no new schemas can be extracted.
+	for changed := true; changed; {
+		changed = false
+		for _, def := range defs {
+			if _, ok := sa.schemas[def.Name]; ok {
+				continue
+			}
+			if schema, ok := sa.macroSchema(def); ok {
+				sa.schemas[def.Name] = schema
+				sa.macros[def.Name] = true
+				changed = true
+			}
+		}
+	}
+}
+
+// attrSchema returns the schema of an attribute declared with an `attr.xxx()` call.
+func attrSchema(expr build.Expr) (lang.AttributeSchema, bool) {
+	call, ok := expr.(*build.CallExpr)
+	if !ok {
+		return lang.AttributeSchema{}, false
+	}
+	dot, ok := call.X.(*build.DotExpr)
+	if !ok {
+		return lang.AttributeSchema{}, false
+	}
+	if ident, ok := dot.X.(*build.Ident); !ok || ident.Name != "attr" {
+		return lang.AttributeSchema{}, false
+	}
+	typ, ok := attrFunctionTypes[dot.Name]
+	if !ok {
+		return lang.AttributeSchema{}, false
+	}
+	schema := lang.AttributeSchema{Type: typ}
+	for _, arg := range call.List {
+		as, ok := arg.(*build.AssignExpr)
+		if !ok {
+			continue
+		}
+		key, ok := as.LHS.(*build.Ident)
+		if !ok {
+			continue
+		}
+		switch key.Name {
+		case "mandatory":
+			if value, ok := as.RHS.(*build.Ident); ok && value.Name == "True" {
+				schema.Mandatory = true
+			}
+		case "default":
+			schema.Default = build.FormatString(as.RHS)
+		}
+	}
+	return schema, true
+}
+
+// attrDict returns the attributes declared by a dict of `attr.xxx()` calls, which can be
+// composed of global variables using `dict()`, `dicts.add()` or the `|` operator.
+// Private attributes (that start with an underscore) are skipped.
+func (sa *schemaAnalyzer) attrDict(expr build.Expr) (map[string]lang.AttributeSchema, bool) {
+	attrs := make(map[string]lang.AttributeSchema)
+	merge := func(expr build.Expr) bool {
+		other, ok := sa.attrDict(expr)
+		for name, schema := range other {
+			attrs[name] = schema
+		}
+		return ok
+	}
+
+	switch expr := expr.(type) {
+	case *build.DictExpr:
+		for _, kv := range expr.List {
+			key, ok := kv.Key.(*build.StringExpr)
+			if !ok {
+				return nil, false
+			}
+			schema, ok := attrSchema(kv.Value)
+			if !ok {
+				return nil, false
+			}
+			if !strings.HasPrefix(key.Value, "_") {
+				attrs[key.Value] = schema
+			}
+		}
+	case *build.Ident:
+		dict, ok := sa.attrDicts[expr.Name]
+		if !ok {
+			return nil, false
+		}
+		for name, schema := range dict {
+			attrs[name] = schema
+		}
+	case *build.BinaryExpr:
+		if expr.Op != "|" || !merge(expr.X) || !merge(expr.Y) {
+			return nil, false
+		}
+	case *build.CallExpr:
+		switch build.FormatString(expr.X) {
+		case "dict", "dicts.add":
+		default:
+			return nil, false
+		}
+		for _, arg := range expr.List {
+			switch arg := arg.(type) {
+			case *build.AssignExpr:
+				key, ok := arg.LHS.(*build.Ident)
+				if !ok {
+					return nil, false
+				}
+				schema, ok := attrSchema(arg.RHS)
+				if !ok {
+					return nil, false
+				}
+				if !strings.HasPrefix(key.Name, "_") {
+					attrs[key.Name] = schema
+				}
+			case *build.UnaryExpr:
+				if arg.Op != "**" || !merge(arg.X) {
+					return nil, false
+				}
+			default:
+				if !merge(arg) {
+					return nil, false
+				}
+			}
+		}
+	default:
+		return nil, false
+	}
+	return attrs, true
+}
+
+// ruleSchema returns the schema of a rule declared with `rule()` or `macro()`.
+func (sa *schemaAnalyzer) ruleSchema(expr build.Expr) (map[string]lang.AttributeSchema, bool) {
+	call, ok := expr.(*build.CallExpr)
+	if !ok {
+		return nil, false
+	}
+	ident, ok := call.X.(*build.Ident)
+	if !ok || (ident.Name != "rule" && ident.Name != "macro") {
+		return nil, false
+	}
+
+	schema := make(map[string]lang.AttributeSchema)
+	if ident.Name == "rule" {
+		for name, attr := range lang.CommonAttributes {
+			schema[name] = attr
+		}
+	} else {
+		schema["visibility"] = lang.CommonAttributes["visibility"]
+	}
+	for _, arg := range call.List {
+		as, ok := arg.(*build.AssignExpr)
+		if !ok {
+			continue
+		}
+		key, ok := as.LHS.(*build.Ident)
+		if !ok {
+			continue
+		}
+		value, _ := as.RHS.(*build.Ident)
+		isTrue := value != nil && value.Name == "True"
+		switch {
+		case key.Name == "attrs":
+			attrs, ok := sa.attrDict(as.RHS)
+			if !ok {
+				return nil, false
+			}
+			for name, attr := range attrs {
+				schema[name] = attr
+			}
+		case key.Name == "inherit_attrs":
+			// Attributes of symbolic macros inherited from other rules are not supported
+			return nil, false
+		case key.Name == "test" && isTrue:
+			for name, attr := range lang.CommonTestAttributes {
+				schema[name] = attr
+			}
+		case key.Name == "executable" && isTrue:
+			schema["args"] = lang.CommonTestAttributes["args"]
+			schema["env"] = lang.CommonTestAttributes["env"]
+		}
+	}
+	schema["name"] = lang.AttributeSchema{Type: buildpb.Attribute_STRING, Mandatory: true}
+	return schema, true
+}
+
+// calleeSchema returns the schema of a rule or a macro called from a macro.
+func (sa *schemaAnalyzer) calleeSchema(call *build.CallExpr) (map[string]lang.AttributeSchema, bool) {
+	switch x := call.X.(type) {
+	case *build.Ident:
+		if schema, ok := sa.schemas[x.Name]; ok {
+			return schema, true
+		}
+		schema, ok := lang.RuleSchemas[x.Name]
+		return schema, ok
+	case *build.DotExpr:
+		if native, ok := x.X.(*build.Ident); ok && native.Name == "native" {
+			schema, ok := lang.RuleSchemas[x.Name]
+			return schema, ok
+		}
+	}
+	return nil, false
+}
+
+// macroSchema returns the schema of a macro defined as a function. Only functions with a `name`
+// parameter are considered macros, the parameters of the function are its attributes, their types
+// are taken from the attributes of the rules they are passed to.
+// If the macro has a `**kwargs` parameter, it should be passed to a rule or a macro with a known
+// schema, whose attributes are then also accepted by the macro.
+func (sa *schemaAnalyzer) macroSchema(def *build.DefStmt) (map[string]lang.AttributeSchema, bool) {
+	schema := make(map[string]lang.AttributeSchema)
+	kwargs := ""
+	for _, param := range def.Params {
+		switch param := param.(type) {
+		case *build.Ident:
+			schema[param.Name] = lang.AttributeSchema{Type: buildpb.Attribute_UNKNOWN, Mandatory: true}
+		case *build.AssignExpr:
+			name, ok := param.LHS.(*build.Ident)
+			if !ok {
+				return nil, false
+			}
+			schema[name.Name] = lang.AttributeSchema{Type: buildpb.Attribute_UNKNOWN, Default: build.FormatString(param.RHS)}
+		case *build.UnaryExpr:
+			name, ok := param.X.(*build.Ident)
+			if !ok {
+				// A bare `*` separating keyword-only parameters
+				continue
+			}
+			if param.Op != "**" {
+				// Macros with *args can't be called with keyword arguments only
+				return nil, false
+			}
+			kwargs = name.Name
+		}
+	}
+	if _, ok := schema["name"]; !ok {
+		// Functions without a `name` parameter are helpers rather than macros
+		return nil, false
+	}
+
+	params := make(map[string]bool)
+	for name := range schema {
+		params[name] = true
+	}
+	kwargsForwarded := false
+	for _, stmt := range def.Body {
+		build.Walk(stmt, func(expr build.Expr, stack []build.Expr) {
+			call, ok := expr.(*build.CallExpr)
+			if !ok {
+				return
+			}
+			callee, ok := sa.calleeSchema(call)
+			if !ok {
+				return
+			}
+			passed := make(map[string]bool)
+			forwardsKwargs := false
+			for _, arg := range call.List {
+				switch arg := arg.(type) {
+				case *build.AssignExpr:
+					key, ok := arg.LHS.(*build.Ident)
+					if !ok {
+						continue
+					}
+					passed[key.Name] = true
+					value, ok := arg.RHS.(*build.Ident)
+					if !ok || !params[value.Name] {
+						continue
+					}
+					if attr, ok := schema[value.Name]; ok && attr.Type == buildpb.Attribute_UNKNOWN {
+						attr.Type = callee[key.Name].Type
+						schema[value.Name] = attr
+					}
+				case *build.UnaryExpr:
+					if value, ok := arg.X.(*build.Ident); ok && arg.Op == "**" && kwargs != "" && value.Name == kwargs {
+						forwardsKwargs = true
+					}
+				}
+			}
+			if !forwardsKwargs {
+				return
+			}
+			kwargsForwarded = true
+			for name, attr := range callee {
+				if _, ok := schema[name]; !ok && !passed[name] {
+					schema[name] = attr
+				}
+			}
+		})
+	}
+	if kwargs != "" && !kwargsForwarded {
+		return nil, false
+	}
+	return schema, true
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warn
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/lang"
+
+	buildpb "github.com/bazelbuild/buildtools/build_proto"
+)
+
+func TestRuleSchemas(t *testing.T) {
+	f, err := build.ParseBzl("test/package/defs.bzl", []byte(`
+load(":unknown.bzl", "unknown_rule")
+
+my_rule = rule(
+    implementation = _impl,
+    attrs = {
+        "srcs": attr.label_list(mandatory = True),
+        "out": attr.output(),
+        "stamp": attr.int(default = -1),
+        "_tool": attr.label(default = "//tools:gen"),
+    },
+)
+
+my_macro = macro(implementation = _impl, attrs = {"deps": attr.label_list()})
+
+dynamic_rule = rule(implementation = _impl, attrs = get_attrs())
+
+def wrapper(name, srcs, stamp = 0, **kwargs):
+    my_rule(name = name, srcs = srcs, stamp = stamp, **kwargs)
+
+def unknown_wrapper(name, **kwargs):
+    unknown_rule(name = name, **kwargs)
+
+def variadic(name, *args):
+    my_rule(name = name, srcs = args)
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Pkg = "test/package"
+	schemas := RuleSchemas(f, nil)
+
+	for _, name := range []string{"dynamic_rule", "unknown_wrapper", "variadic", "unknown_rule"} {
+		if _, ok := schemas[name]; ok {
+			t.Errorf("RuleSchemas() contains %q, want it omitted", name)
+		}
+	}
+
+	want := map[string]lang.AttributeSchema{
+		"name":  {Type: buildpb.Attribute_STRING, Mandatory: true},
+		"srcs":  {Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"out":   {Type: buildpb.Attribute_OUTPUT},
+		"stamp": {Type: buildpb.Attribute_INTEGER, Default: "-1"},
+	}
+	for name, attr := range want {
+		if got := schemas["my_rule"][name]; got != attr {
+			t.Errorf("RuleSchemas()[%q][%q] = %v, want %v", "my_rule", name, got, attr)
+		}
+	}
+	if _, ok := schemas["my_rule"]["_tool"]; ok {
+		t.Errorf("RuleSchemas()[%q] contains the private attribute %q", "my_rule", "_tool")
+	}
+	if _, ok := schemas["my_rule"]["visibility"]; !ok {
+		t.Errorf("RuleSchemas()[%q] doesn't contain the common attribute %q", "my_rule", "visibility")
+	}
+
+	wantMacro := map[string]lang.AttributeSchema{
+		"name":       {Type: buildpb.Attribute_STRING, Mandatory: true},
+		"visibility": {Type: buildpb.Attribute_STRING_LIST},
+		"deps":       {Type: buildpb.Attribute_LABEL_LIST},
+	}
+	if got := schemas["my_macro"]; !reflect.DeepEqual(got, wantMacro) {
+		t.Errorf("RuleSchemas()[%q] = %v, want %v", "my_macro", got, wantMacro)
+	}
+
+	want = map[string]lang.AttributeSchema{
+		"name":  {Type: buildpb.Attribute_STRING, Mandatory: true},
+		"srcs":  {Type: buildpb.Attribute_LABEL_LIST, Mandatory: true},
+		"stamp": {Type: buildpb.Attribute_INTEGER, Default: "0"},
+		"out":   {Type: buildpb.Attribute_OUTPUT},
+		"tags":  {Type: buildpb.Attribute_STRING_LIST},
+	}
+	for name, attr := range want {
+		if got := schemas["wrapper"][name]; got != attr {
+			t.Errorf("RuleSchemas()[%q][%q] = %v, want %v", "wrapper", name, got, attr)
+		}
+	}
+}
@@ -17,191 +17,64 @@ limitations under the License.
 package warn
 
 import (
-	"regexp"
-
 	"github.com/bazelbuild/buildtools/build"
-	"github.com/bazelbuild/buildtools/bzlenv"
+	"github.com/bazelbuild/buildtools/types"
 )
 
 // Type describes an expression type in Starlark.
-type Type int
+type Type = types.Type
 
 // List of known types
 const (
-	Unknown Type = iota
-	Bool
-	Ctx
-	CtxActions
-	CtxActionsArgs
-	Depset
-	Dict
-	Int
-	None
-	String
-	List
-	Float
+	Unknown        = types.Unknown
+	Bool           = types.Bool
+	Ctx            = types.Ctx
+	CtxActions     = types.CtxActions
+	CtxActionsArgs = types.CtxActionsArgs
+	Depset         = types.Depset
+	Dict           = types.Dict
+	Int            = types.Int
+	None           = types.None
+	String         = types.String
+	List           = types.List
+	Float          = types.Float
+	Tuple          = types.Tuple
+	Label          = types.Label
+	File           = types.File
+	Function       = types.Function
+	Struct         = types.Struct
 )
 
-func (t Type) String() string {
-	return [...]string{
-		"unknown",
-		"bool",
-		"ctx",
-		"ctx.actions",
-		"ctx.actions.args",
-		"depset",
-		"dict",
-		"int",
-		"none",
-		"string",
-		"list",
-	}[t]
+// typeInfo returns the inferred types of expressions of the file. Types of symbols
+// loaded from other files are resolved using the file reader, which can be nil.
+// The results are cached by the file reader until invalidateTypes is called.
+func (fr *FileReader) typeInfo(f *build.File) *types.Info {
+	if fr == nil {
+		return fr.typeInferrer().Infer(f)
+	}
+	if info, ok := fr.typeInfos[f]; ok {
+		return info
+	}
+	if fr.typeInfos == nil {
+		fr.typeInfos = make(map[*build.File]*types.Info)
+	}
+	info := fr.typeInferrer().Infer(f)
+	fr.typeInfos[f] = info
+	return info
 }
 
-var intRegexp = regexp.MustCompile(`^([0-9]+|0[Xx][0-9A-Fa-f]+|0[Oo][0-7]+)$`)
-
-func detectTypes(f *build.File) map[build.Expr]Type {
-	variables := make(map[int]Type)
-	result := make(map[build.Expr]Type)
-
-	var walk func(e *build.Expr, env *bzlenv.Environment)
-	walk = func(e *build.Expr, env *bzlenv.Environment) {
-		// Postorder: determining types of subnodes may help with this node's type
-		walkOnce(*e, env, walk)
-
-		nodeType := Unknown
-		defer func() {
-			if nodeType != Unknown {
-				result[*e] = nodeType
-			}
-		}()
-
-		switch node := (*e).(type) {
-		case *build.StringExpr:
-			nodeType = String
-		case *build.DictExpr:
-			nodeType = Dict
-		case *build.ListExpr:
-			nodeType = List
-		case *build.LiteralExpr:
-			if intRegexp.MatchString(node.Token) {
-				nodeType = Int
-			} else {
-				nodeType = Float
-			}
-		case *build.Comprehension:
-			if node.Curly {
-				nodeType = Dict
-			} else {
-				nodeType = List
-			}
-		case *build.CallExpr:
-			if ident, ok := (node.X).(*build.Ident); ok {
-				switch ident.Name {
-				case "depset":
-					nodeType = Depset
-				case "dict":
-					nodeType = Dict
-				case "list":
-					nodeType = List
-				}
-			} else if dot, ok := (node.X).(*build.DotExpr); ok {
-				if result[dot.X] == CtxActions && dot.Name == "args" {
-					nodeType = CtxActionsArgs
-				}
-			}
-		case *build.ParenExpr:
-			nodeType = result[node.X]
-		case *build.Ident:
-			switch node.Name {
-			case "True", "False":
-				nodeType = Bool
-				return
-			case "None":
-				nodeType = None
-				return
-			case "ctx":
-				binding := env.Get(node.Name)
-				if binding != nil && binding.Kind == bzlenv.Parameter {
-					nodeType = Ctx
-					return
-				}
-			}
-			binding := env.Get(node.Name)
-			if binding != nil {
-				if t, ok := variables[binding.ID]; ok {
-					nodeType = t
-				}
-			}
-		case *build.DotExpr:
-			if result[node.X] == Ctx && node.Name == "actions" {
-				nodeType = CtxActions
-			}
-		case *build.BinaryExpr:
-			switch node.Op {
-			case ">", ">=", "<", "<=", "==", "!=", "in", "not in":
-				// Boolean
-				nodeType = Bool
-
-			case "+", "-", "*", "/", "//", "%", "|":
-				// We assume these operators can only applied to expressions of the same type and
-				// preserve the type
-				if t, ok := result[node.X]; ok {
-					nodeType = t
-				} else if t, ok := result[node.Y]; ok {
-					if node.Op != "%" || t == String {
-						// The percent operator is special because it can be applied to to arguments of
-						// different types (`"%s\n" % foo`), and we can't assume that the expression has
-						// type X if the right-hand side has the type X.
-						nodeType = t
-					}
-				}
-			}
-		case *build.AssignExpr:
-			t, ok := result[node.RHS]
-			if !ok {
-				return
-			}
-			if node.Op == "%=" && t != String {
-				// If the right hand side is not a string, the left hand side can still be a string
-				return
-			}
-			ident, ok := (node.LHS).(*build.Ident)
-			if !ok {
-				return
-			}
-			binding := env.Get(ident.Name)
-			if binding == nil {
-				return
-			}
-			variables[binding.ID] = t
-		}
+// invalidateTypes should be called after the file is modified, e.g. by applied fixes.
+// Can be called on a nil FileReader.
+func (fr *FileReader) invalidateTypes(f *build.File) {
+	if fr != nil {
+		delete(fr.typeInfos, f)
 	}
-	var expr build.Expr = f
-	walk(&expr, bzlenv.NewEnvironment())
-
-	return result
 }
 
-// walkOnce is a wrapper for bzlint.WalkOnceWithEnvironment which skips the left hand side for
-// named parameters of functions. E.g. for `foo(x, y = z)` it visits `foo`, `x`, and `z`.
-// In the following example `x` in the last line shouldn't be recognised as int, but 'y' should:
-//
-//    x = 3
-//    y = 5
-//    foo(x = y)
-func walkOnce(node build.Expr, env *bzlenv.Environment, fct func(e *build.Expr, env *bzlenv.Environment)) {
-	switch expr := node.(type) {
-	case *build.CallExpr:
-		fct(&expr.X, env)
-		for _, param := range expr.List {
-			if as, ok := param.(*build.AssignExpr); ok {
-				fct(&as.RHS, env)
-			} else {
-				fct(&param, env)
-			}
-		}
-	default:
-		bzlenv.WalkOnceWithEnvironment(expr, env, fct)
-	}
+// TypeOf returns the inferred type of an expression of the file, or Unknown.
+// Types of symbols loaded from other files are resolved using the file reader,
+// which can be nil. The types are cached until the file is modified by fixes
+// applied by FileWarnings.
+func (fr *FileReader) TypeOf(f *build.File, expr build.Expr) Type {
+	return fr.typeInfo(f).TypeOf(expr)
 }
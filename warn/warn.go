@@ -22,9 +22,9 @@ import (
 	"log"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/bazelbuild/buildtools/build"
-	"github.com/bazelbuild/buildtools/edit"
 )
 
 // LintMode is an enum representing a linter mode. Can be either "warn", "fix", or "suggest"
@@ -56,6 +56,48 @@ type LinterReplacement struct {
 	New build.Expr
 }
 
+// statementList can be used as a replacement of a top-level statement to replace it with
+// several statements, e.g. to insert new statements after an existing one. The statements are
+// inserted into the file when the replacement is applied, see expandStatementLists.
+type statementList struct {
+	build.Comments
+	List []build.Expr
+}
+
+// Span returns the span of the first and the last statements of the list.
+func (s *statementList) Span() (start, end build.Position) {
+	if len(s.List) == 0 {
+		return
+	}
+	start, _ = s.List[0].Span()
+	_, end = s.List[len(s.List)-1].Span()
+	return start, end
+}
+
+// Copy returns a shallow copy of the list.
+func (s *statementList) Copy() build.Expr {
+	n := *s
+	return &n
+}
+
+// expandStatementLists replaces the statement lists among the top-level statements of
+// the file with their statements.
+func expandStatementLists(f *build.File) {
+	var stmts []build.Expr
+	expanded := false
+	for _, stmt := range f.Stmt {
+		if list, ok := stmt.(*statementList); ok {
+			stmts = append(stmts, list.List...)
+			expanded = true
+			continue
+		}
+		stmts = append(stmts, stmt)
+	}
+	if expanded {
+		f.Stmt = stmts
+	}
+}
+
 // A Finding is a warning reported by the analyzer. It may contain an optional suggested fix.
 type Finding struct {
 	File        *build.File
@@ -77,6 +119,9 @@ type Replacement struct {
 }
 
 func docURL(cat string) string {
+	if opts, ok := registeredWarnings[cat]; ok && opts.URL != "" {
+		return opts.URL
+	}
 	return "https://github.com/bazelbuild/buildtools/blob/master/WARNINGS.md#" + cat
 }
 
@@ -122,15 +167,16 @@ var FileWarningMap = map[string]func(f *build.File) []*LinterFinding{
 	"attr-output-default":       attrOutputDefaultWarning,
 	"attr-single-file":          attrSingleFileWarning,
 	"build-args-kwargs":         argsKwargsInBuildFilesWarning,
+	"builtin-args":              singleFileWarning(builtinArgsWarning),
 	"bzl-visibility":            bzlVisibilityWarning,
 	"confusing-name":            confusingNameWarning,
 	"constant-glob":             constantGlobWarning,
 	"ctx-actions":               ctxActionsWarning,
-	"ctx-args":                  contextArgsAPIWarning,
-	"depset-items":              depsetItemsWarning,
-	"depset-iteration":          depsetIterationWarning,
-	"depset-union":              depsetUnionWarning,
-	"dict-concatenation":        dictionaryConcatenationWarning,
+	"ctx-args":                  singleFileWarning(contextArgsAPIWarning),
+	"depset-items":              singleFileWarning(depsetItemsWarning),
+	"depset-iteration":          singleFileWarning(depsetIterationWarning),
+	"depset-union":              singleFileWarning(depsetUnionWarning),
+	"dict-concatenation":        singleFileWarning(dictionaryConcatenationWarning),
 	"duplicated-name":           duplicatedNameWarning,
 	"filetype":                  fileTypeWarning,
 	"function-docstring":        functionDocstringWarning,
@@ -139,12 +185,18 @@ var FileWarningMap = map[string]func(f *build.File) []*LinterFinding{
 	"function-docstring-return": functionDocstringReturnWarning,
 	"git-repository":            nativeGitRepositoryWarning,
 	"http-archive":              nativeHTTPArchiveWarning,
-	"integer-division":          integerDivisionWarning,
+	"integer-division":          singleFileWarning(integerDivisionWarning),
 	"keyword-positional-params": keywordPositionalParametersWarning,
+	"layering":                  layeringWarning,
 	"list-append":               listAppendWarning,
 	"load":                      unusedLoadWarning,
 	"load-on-top":               loadOnTopWarning,
+	"module-dev-dependency":     moduleDevDependencyWarning,
 	"module-docstring":          moduleDocstringWarning,
+	"module-duplicated-dep":     moduleDuplicatedDepWarning,
+	"module-on-top":             moduleOnTopWarning,
+	"module-override":           moduleOverrideWarning,
+	"module-unsorted-dep":       moduleUnsortedDepWarning,
 	"name-conventions":          nameConventionsWarning,
 	"native-android":            nativeAndroidRulesWarning,
 	"native-build":              nativeInBuildFilesWarning,
@@ -159,26 +211,49 @@ var FileWarningMap = map[string]func(f *build.File) []*LinterFinding{
 	"overly-nested-depset":      overlyNestedDepsetWarning,
 	"package-name":              packageNameWarning,
 	"package-on-top":            packageOnTopWarning,
+	"policy":                    policyWarning,
 	"print":                     printWarning,
 	"provider-params":           providerParamsWarning,
-	"redefined-variable":        redefinedVariableWarning,
+	"redefined-variable":        singleFileWarning(redefinedVariableWarning),
 	"repository-name":           repositoryNameWarning,
 	"rule-impl-return":          ruleImplReturnWarning,
 	"return-value":              missingReturnValueWarning,
 	"same-origin-load":          sameOriginLoadWarning,
 	"skylark-comment":           skylarkCommentWarning,
 	"skylark-docstring":         skylarkDocstringWarning,
-	"string-iteration":          stringIterationWarning,
+	"string-iteration":          singleFileWarning(stringIterationWarning),
 	"uninitialized":             uninitializedVariableWarning,
 	"unreachable":               unreachableStatementWarning,
 	"unsorted-dict-items":       unsortedDictItemsWarning,
 	"unused-variable":           unusedVariableWarning,
 }
 
+// typedFileWarnings contains the implementations of the warnings in FileWarningMap that use
+// inferred types. FileWarnings runs them with its FileReader, so that the types of loaded symbols
+// are resolved and the results of type inference are shared between the warnings.
+var typedFileWarnings = map[string]func(f *build.File, fileReader *FileReader) []*LinterFinding{
+	"builtin-args":       builtinArgsWarning,
+	"ctx-args":           contextArgsAPIWarning,
+	"depset-items":       depsetItemsWarning,
+	"depset-iteration":   depsetIterationWarning,
+	"depset-union":       depsetUnionWarning,
+	"dict-concatenation": dictionaryConcatenationWarning,
+	"integer-division":   integerDivisionWarning,
+	"redefined-variable": redefinedVariableWarning,
+	"string-iteration":   stringIterationWarning,
+}
+
 // MultiFileWarningMap lists the warnings that run on the whole file, but may use other files.
 var MultiFileWarningMap = map[string]func(f *build.File, fileReader *FileReader) []*LinterFinding{
-	"deprecated-function": deprecatedFunctionWarning,
-	"unnamed-macro":       unnamedMacroWarning,
+	"dependency-cycle":        dependencyCycleWarning,
+	"deprecated-function":     deprecatedFunctionWarning,
+	"load-exports":            loadExportsWarning,
+	"module-unknown-use-repo": moduleUnknownUseRepoWarning,
+	"package-cycle":           packageCycleWarning,
+	"rule-attributes":         ruleAttributesWarning,
+	"target-visibility":       targetVisibilityWarning,
+	"unnamed-macro":           unnamedMacroWarning,
+	"unresolved-label":        unresolvedLabelWarning,
 }
 
 // nonDefaultWarnings contains warnings that are enabled by default because they're not applicable
@@ -191,6 +266,15 @@ var nonDefaultWarnings = map[string]bool{
 	"native-java":         true, // disables native java rules
 	"native-proto":        true, // disables native proto rules
 	"native-py":           true, // disables native python rules
+	"module-override":     true, // overrides are only problematic in non-root modules
+	"module-unsorted-dep": true, // bazel_dep statements should be sorted
+	"load-exports":        true, // re-exports may be intentional public interfaces of private implementations
+	"dependency-cycle":    true, // requires reading the BUILD files of all transitive dependencies
+	"package-cycle":       true, // requires reading the BUILD files of all transitive dependencies
+	"rule-attributes":     true, // the schemas of native rules may not match the used Bazel version
+	"target-visibility":   true, // requires reading the BUILD files of all dependencies
+	"unresolved-label":    true, // targets may be generated in ways that can't be analyzed statically
+	"unused-suppression":  true, // suppressions of warnings that are not enabled can't be checked
 }
 
 // fileWarningWrapper is a wrapper that converts a file warning function to a generic function.
@@ -202,6 +286,14 @@ func fileWarningWrapper(fct func(f *build.File) []*LinterFinding) func(*build.Fi
 	}
 }
 
+// singleFileWarning converts a warning that can use other files to a file warning, which
+// doesn't resolve symbols loaded from other files.
+func singleFileWarning(fct func(f *build.File, fileReader *FileReader) []*LinterFinding) func(f *build.File) []*LinterFinding {
+	return func(f *build.File) []*LinterFinding {
+		return fct(f, nil)
+	}
+}
+
 // multiFileWarningWrapper is a wrapper that converts a multifile warning function to a generic function.
 // A generic function takes a `pkg string` argument which is not used for file warnings, so it's just removed.
 func multiFileWarningWrapper(fct func(f *build.File, fileReader *FileReader) []*LinterFinding) func(*build.File, string, *FileReader) []*LinterFinding {
@@ -240,10 +332,10 @@ func ruleWarningWrapper(ruleWarning func(call *build.CallExpr, pkg string) *Lint
 }
 
 // runWarningsFunction runs a linter/fixer function over a file and applies the fixes conditionally
-func runWarningsFunction(category string, f *build.File, fct func(f *build.File, pkg string, fileReader *FileReader) []*LinterFinding, formatted *[]byte, mode LintMode, fileReader *FileReader) []*Finding {
+func runWarningsFunction(category string, f *build.File, fct func(f *build.File, pkg string, fileReader *FileReader) []*LinterFinding, formatted *[]byte, mode LintMode, fileReader *FileReader, suppressions *fileSuppressions) []*Finding {
 	findings := []*Finding{}
 	for _, w := range fct(f, f.Pkg, fileReader) {
-		if !DisabledWarning(f, w.Start.Line, category) {
+		if !suppressions.disabled(w.Start.Line, category) {
 			finding := makeFinding(f, w.Start, w.End, category, w.URL, w.Message, true, nil)
 			if len(w.Replacement) > 0 {
 				// An automatic fix exists
@@ -253,6 +345,9 @@ func runWarningsFunction(category string, f *build.File, fct func(f *build.File,
 					for _, r := range w.Replacement {
 						*r.Old = r.New
 					}
+					expandStatementLists(f)
+					suppressions.invalidate()
+					fileReader.invalidateTypes(f)
 					finding = nil
 				case ModeSuggest:
 					// Apply the fix, calculate the diff and roll back the fix
@@ -275,41 +370,35 @@ func runWarningsFunction(category string, f *build.File, fct func(f *build.File,
 	return findings
 }
 
-// HasDisablingComment checks if a node has a comment that disables a certain warning
+// HasDisablingComment checks if a node has a comment that disables a certain warning.
+// Comments and warning names are matched case-insensitively, the same way as by DisabledWarning.
 func HasDisablingComment(expr build.Expr, warning string) bool {
-	return edit.ContainsComments(expr, "buildifier: disable="+warning) ||
-		edit.ContainsComments(expr, "buildozer: disable="+warning)
+	comments := expr.Comment()
+	for _, list := range [][]build.Comment{comments.Before, comments.Suffix, comments.After} {
+		for _, c := range list {
+			if isDisablingComment(c.Token, warning) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isDisablingComment checks if a comment disables a certain warning, see HasDisablingComment.
+func isDisablingComment(token, warning string) bool {
+	token = strings.ToLower(token)
+	warning = strings.ToLower(warning)
+	return strings.Contains(token, "buildifier: disable="+warning) ||
+		strings.Contains(token, "buildozer: disable="+warning)
 }
 
 // DisabledWarning checks if the warning was disabled by a comment.
-// The comment format is buildozer: disable=<warning>
+// The comment format is buildozer: disable=<warning>, the warning can also be disabled
+// for the whole file by a `disable-file=<warning>` comment before the first statement,
+// for the next line by `disable-next-line=<warning>`, or for a region of lines between
+// `disable-start=<warning>` and `disable-end=<warning>` comments.
 func DisabledWarning(f *build.File, findingLine int, warning string) bool {
-	disabled := false
-
-	build.Walk(f, func(expr build.Expr, stack []build.Expr) {
-		if expr == nil {
-			return
-		}
-
-		start, end := expr.Span()
-		comments := expr.Comment()
-		if len(comments.Before) > 0 {
-			start, _ = comments.Before[0].Span()
-		}
-		if len(comments.After) > 0 {
-			_, end = comments.After[len(comments.After)-1].Span()
-		}
-		if findingLine < start.Line || findingLine > end.Line {
-			return
-		}
-
-		if HasDisablingComment(expr, warning) {
-			disabled = true
-			return
-		}
-	})
-
-	return disabled
+	return len(newSuppressionIndex(f).disablingComments(findingLine, warning)) > 0
 }
 
 // FileWarnings returns a list of all warnings found in the file.
@@ -327,16 +416,37 @@ func FileWarnings(f *build.File, enabledWarnings []string, formatted *[]byte, mo
 		formatted = &contents
 	}
 
+	suppressions := newFileSuppressions(f)
+	ran := make(map[string]bool)
+	checkSuppressions := false
 	for _, warn := range warnings {
-		if fct, ok := FileWarningMap[warn]; ok {
-			findings = append(findings, runWarningsFunction(warn, f, fileWarningWrapper(fct), formatted, mode, fileReader)...)
+		if fct, ok := typedFileWarnings[warn]; ok {
+			findings = append(findings, runWarningsFunction(warn, f, multiFileWarningWrapper(fct), formatted, mode, fileReader, suppressions)...)
+		} else if fct, ok := FileWarningMap[warn]; ok {
+			findings = append(findings, runWarningsFunction(warn, f, fileWarningWrapper(fct), formatted, mode, fileReader, suppressions)...)
 		} else if fct, ok := MultiFileWarningMap[warn]; ok {
-			findings = append(findings, runWarningsFunction(warn, f, multiFileWarningWrapper(fct), formatted, mode, fileReader)...)
+			findings = append(findings, runWarningsFunction(warn, f, multiFileWarningWrapper(fct), formatted, mode, fileReader, suppressions)...)
+			if fileReader == nil {
+				// The warning may have skipped the checks that need other files,
+				// so it's unknown whether its suppressions are used.
+				continue
+			}
 		} else if fct, ok := RuleWarningMap[warn]; ok {
-			findings = append(findings, runWarningsFunction(warn, f, ruleWarningWrapper(fct), formatted, mode, fileReader)...)
+			findings = append(findings, runWarningsFunction(warn, f, ruleWarningWrapper(fct), formatted, mode, fileReader, suppressions)...)
+		} else if warn == unusedSuppressionCategory {
+			// Depends on the results of other warnings and should run after them
+			checkSuppressions = true
+			continue
 		} else {
 			log.Fatalf("unexpected warning %q", warn)
 		}
+		ran[warn] = true
+	}
+	if checkSuppressions {
+		fct := func(f *build.File, pkg string, fileReader *FileReader) []*LinterFinding {
+			return suppressionWarning(f, ran, suppressions.used)
+		}
+		findings = append(findings, runWarningsFunction(unusedSuppressionCategory, f, fct, formatted, mode, fileReader, suppressions)...)
 	}
 	sort.Slice(findings, func(i, j int) bool { return findings[i].Start.Line < findings[j].Start.Line })
 	return findings
@@ -351,6 +461,10 @@ func formatWithFix(f *build.File, replacements *[]LinterReplacement) []byte {
 		defer func() { *r.Old = old }()
 	}
 
+	stmts := f.Stmt
+	expandStatementLists(f)
+	defer func() { f.Stmt = stmts }()
+
 	return build.Format(f)
 }
 
@@ -411,6 +525,7 @@ func collectAllWarnings() []string {
 	for k := range RuleWarningMap {
 		result = append(result, k)
 	}
+	result = append(result, unusedSuppressionCategory)
 	sort.Strings(result)
 	return result
 }
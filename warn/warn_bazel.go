@@ -20,9 +20,15 @@ package warn
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/bzlenv"
+	"github.com/bazelbuild/buildtools/lang"
+
+	buildpb "github.com/bazelbuild/buildtools/build_proto"
 )
 
 var functionsWithPositionalArguments = map[string]bool{
@@ -220,3 +226,157 @@ func printWarning(f *build.File) []*LinterFinding {
 	})
 	return findings
 }
+
+// literalKind returns the kind of a literal expression ("string", "list", "dict", "boolean"
+// or "integer"), or an empty string if the expression is not a literal.
+func literalKind(expr build.Expr) string {
+	switch expr := expr.(type) {
+	case *build.StringExpr:
+		return "string"
+	case *build.ListExpr:
+		return "list"
+	case *build.DictExpr:
+		return "dict"
+	case *build.Comprehension:
+		if expr.Curly {
+			return "dict"
+		}
+		return "list"
+	case *build.Ident:
+		if expr.Name == "True" || expr.Name == "False" {
+			return "boolean"
+		}
+	case *build.LiteralExpr:
+		if _, err := strconv.ParseInt(expr.Token, 0, 64); err == nil {
+			return "integer"
+		}
+	case *build.UnaryExpr:
+		if expr.Op == "-" && literalKind(expr.X) == "integer" {
+			return "integer"
+		}
+	}
+	return ""
+}
+
+// attributeKinds returns the kinds of literals that can be assigned to an attribute of the
+// given type, the first one is the preferred kind. Returns nil if the type is not checked.
+func attributeKinds(t buildpb.Attribute_Discriminator) []string {
+	switch t {
+	case buildpb.Attribute_STRING, buildpb.Attribute_LABEL, buildpb.Attribute_OUTPUT:
+		return []string{"string"}
+	case buildpb.Attribute_STRING_LIST, buildpb.Attribute_LABEL_LIST, buildpb.Attribute_OUTPUT_LIST,
+		buildpb.Attribute_INTEGER_LIST, buildpb.Attribute_DISTRIBUTION_SET, buildpb.Attribute_LICENSE:
+		return []string{"list"}
+	case buildpb.Attribute_STRING_DICT, buildpb.Attribute_STRING_LIST_DICT, buildpb.Attribute_LABEL_DICT_UNARY,
+		buildpb.Attribute_LABEL_KEYED_STRING_DICT, buildpb.Attribute_LABEL_LIST_DICT:
+		return []string{"dict"}
+	case buildpb.Attribute_BOOLEAN:
+		return []string{"boolean", "integer"}
+	case buildpb.Attribute_INTEGER, buildpb.Attribute_TRISTATE:
+		return []string{"integer", "boolean"}
+	}
+	return nil
+}
+
+// checkRuleAttributes validates the arguments of a rule call against the schema of the rule.
+func checkRuleAttributes(call *build.CallExpr, kind string, schema map[string]lang.AttributeSchema) []*LinterFinding {
+	var findings []*LinterFinding
+	passed := make(map[string]bool)
+	// Whether the call contains positional arguments or unpacked *args or **kwargs, then
+	// it's unknown which attributes are passed
+	unknownArgs := false
+	for _, arg := range call.List {
+		as, ok := arg.(*build.AssignExpr)
+		if !ok {
+			unknownArgs = true
+			continue
+		}
+		key, ok := as.LHS.(*build.Ident)
+		if !ok {
+			continue
+		}
+		passed[key.Name] = true
+		attr, ok := schema[key.Name]
+		if !ok {
+			findings = append(findings, makeLinterFinding(key,
+				fmt.Sprintf("Rule %q has no attribute %q.", kind, key.Name)))
+			continue
+		}
+		kinds := attributeKinds(attr.Type)
+		actual := literalKind(as.RHS)
+		if len(kinds) == 0 || actual == "" {
+			continue
+		}
+		matches := false
+		for _, k := range kinds {
+			if k == actual {
+				matches = true
+			}
+		}
+		if !matches {
+			findings = append(findings, makeLinterFinding(as.RHS,
+				fmt.Sprintf("The attribute %q of %q should be of type %s, not %s.", key.Name, kind, kinds[0], actual)))
+		}
+	}
+
+	if unknownArgs {
+		return findings
+	}
+	var missing []string
+	for name, attr := range schema {
+		if attr.Mandatory && !passed[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		findings = append(findings, makeLinterFinding(call,
+			fmt.Sprintf("Missing mandatory attribute %q of %q.", name, kind)))
+	}
+	return findings
+}
+
+// ruleAttributesWarning validates calls of native rules against their schemas, as well as
+// calls of Starlark rules and macros defined in the file or loaded from other files.
+// Macros are only validated in BUILD files, in other files functions with a `name`
+// parameter are not necessarily macros.
+func ruleAttributesWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
+	var findings []*LinterFinding
+	available := analyzeSchemas(f, fileReader)
+
+	var walk func(e *build.Expr, env *bzlenv.Environment)
+	walk = func(e *build.Expr, env *bzlenv.Environment) {
+		defer bzlenv.WalkOnceWithEnvironment(*e, env, walk)
+
+		call, ok := (*e).(*build.CallExpr)
+		if !ok {
+			return
+		}
+		var kind string
+		var schema map[string]lang.AttributeSchema
+		switch x := call.X.(type) {
+		case *build.Ident:
+			kind = x.Name
+			switch binding := env.Get(x.Name); {
+			case binding == nil:
+				schema = lang.RuleSchemas[kind]
+			case binding.Kind == bzlenv.Imported || binding.Kind == bzlenv.Global || binding.Kind == bzlenv.Function:
+				if f.Type == build.TypeBuild || !available.macros[kind] {
+					schema = available.schemas[kind]
+				}
+			}
+		case *build.DotExpr:
+			if native, ok := x.X.(*build.Ident); ok && native.Name == "native" && env.Get(native.Name) == nil {
+				kind = x.Name
+				schema = lang.RuleSchemas[kind]
+			}
+		}
+		if schema != nil {
+			findings = append(findings, checkRuleAttributes(call, kind, schema)...)
+		}
+	}
+	var expr build.Expr = f
+	walk(&expr, bzlenv.NewEnvironment())
+
+	return findings
+}
@@ -380,10 +380,10 @@ func attrConfigurationWarning(f *build.File) []*LinterFinding {
 	return findings
 }
 
-func depsetItemsWarning(f *build.File) []*LinterFinding {
+func depsetItemsWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
 	var findings []*LinterFinding
 
-	types := detectTypes(f)
+	info := fileReader.typeInfo(f)
 	build.WalkPointers(f, func(expr *build.Expr, stack []build.Expr) {
 		call, ok := (*expr).(*build.CallExpr)
 		if !ok {
@@ -406,7 +406,7 @@ func depsetItemsWarning(f *build.File) []*LinterFinding {
 			return
 		}
 		// We have an unnamed first parameter. Check the type.
-		if types[call.List[0]] == Depset {
+		if info.TypeOf(call.List[0]) == Depset {
 			findings = append(findings,
 				makeLinterFinding(call.List[0], `Giving a depset as first unnamed parameter to depset() is deprecated, use the "transitive" parameter instead.`))
 		}
@@ -715,13 +715,13 @@ func nativeProtoRulesWarning(f *build.File) []*LinterFinding {
 	return notLoadedUsageCheck(f, tables.ProtoNativeRules, tables.ProtoNativeSymbols, tables.ProtoLoadPath)
 }
 
-func contextArgsAPIWarning(f *build.File) []*LinterFinding {
+func contextArgsAPIWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
 	if f.Type != build.TypeBzl {
 		return nil
 	}
 
 	var findings []*LinterFinding
-	types := detectTypes(f)
+	info := fileReader.typeInfo(f)
 
 	build.WalkPointers(f, func(expr *build.Expr, stack []build.Expr) {
 		// Search for `<ctx.actions.args>.add()` nodes
@@ -730,7 +730,7 @@ func contextArgsAPIWarning(f *build.File) []*LinterFinding {
 			return
 		}
 		dot, ok := call.X.(*build.DotExpr)
-		if !ok || dot.Name != "add" || types[dot.X] != CtxActionsArgs {
+		if !ok || dot.Name != "add" || info.TypeOf(dot.X) != CtxActionsArgs {
 			return
 		}
 
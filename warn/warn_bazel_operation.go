@@ -24,19 +24,19 @@ import (
 	"github.com/bazelbuild/buildtools/build"
 )
 
-func depsetUnionWarning(f *build.File) []*LinterFinding {
+func depsetUnionWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
 	var findings []*LinterFinding
 	addWarning := func(expr build.Expr) {
 		findings = append(findings,
 			makeLinterFinding(expr, `Depsets should be joined using the "depset()" constructor.`))
 	}
 
-	types := detectTypes(f)
+	info := fileReader.typeInfo(f)
 	build.Walk(f, func(expr build.Expr, stack []build.Expr) {
 		switch expr := expr.(type) {
 		case *build.BinaryExpr:
 			// `depset1 + depset2` or `depset1 | depset2`
-			if types[expr.X] != Depset && types[expr.Y] != Depset {
+			if info.TypeOf(expr.X) != Depset && info.TypeOf(expr.Y) != Depset {
 				return
 			}
 			switch expr.Op {
@@ -45,7 +45,7 @@ func depsetUnionWarning(f *build.File) []*LinterFinding {
 			}
 		case *build.AssignExpr:
 			// `depset1 += depset2` or `depset1 |= depset2`
-			if types[expr.LHS] != Depset && types[expr.RHS] != Depset {
+			if info.TypeOf(expr.LHS) != Depset && info.TypeOf(expr.RHS) != Depset {
 				return
 			}
 			switch expr.Op {
@@ -64,7 +64,7 @@ func depsetUnionWarning(f *build.File) []*LinterFinding {
 			if dot.Name != "union" {
 				return
 			}
-			if types[dot.X] != Depset && types[expr.List[0]] != Depset {
+			if info.TypeOf(dot.X) != Depset && info.TypeOf(expr.List[0]) != Depset {
 				return
 			}
 			addWarning(expr)
@@ -73,7 +73,7 @@ func depsetUnionWarning(f *build.File) []*LinterFinding {
 	return findings
 }
 
-func depsetIterationWarning(f *build.File) []*LinterFinding {
+func depsetIterationWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
 	var findings []*LinterFinding
 
 	addFinding := func(expr *build.Expr) {
@@ -89,16 +89,16 @@ func depsetIterationWarning(f *build.File) []*LinterFinding {
 			makeLinterFinding(*expr, `Depset iteration is deprecated, use the "to_list()" method instead.`, LinterReplacement{expr, newNode}))
 	}
 
-	types := detectTypes(f)
+	info := fileReader.typeInfo(f)
 	build.WalkPointers(f, func(e *build.Expr, stack []build.Expr) {
 		switch expr := (*e).(type) {
 		case *build.ForStmt:
-			if types[expr.X] != Depset {
+			if info.TypeOf(expr.X) != Depset {
 				return
 			}
 			addFinding(&expr.X)
 		case *build.ForClause:
-			if types[expr.X] != Depset {
+			if info.TypeOf(expr.X) != Depset {
 				return
 			}
 			addFinding(&expr.X)
@@ -106,7 +106,7 @@ func depsetIterationWarning(f *build.File) []*LinterFinding {
 			if expr.Op != "in" && expr.Op != "not in" {
 				return
 			}
-			if types[expr.Y] != Depset {
+			if info.TypeOf(expr.Y) != Depset {
 				return
 			}
 			addFinding(&expr.Y)
@@ -120,7 +120,7 @@ func depsetIterationWarning(f *build.File) []*LinterFinding {
 				if len(expr.List) != 1 {
 					return
 				}
-				if types[expr.List[0]] != Depset {
+				if info.TypeOf(expr.List[0]) != Depset {
 					return
 				}
 				addFinding(&expr.List[0])
@@ -130,7 +130,7 @@ func depsetIterationWarning(f *build.File) []*LinterFinding {
 				}
 			case "zip":
 				for i, arg := range expr.List {
-					if types[arg] != Depset {
+					if info.TypeOf(arg) != Depset {
 						continue
 					}
 					addFinding(&expr.List[i])
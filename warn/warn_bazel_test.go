@@ -145,3 +145,173 @@ def f(x):
 		},
 		scopeBazel)
 }
+
+func TestRuleAttributesWarning(t *testing.T) {
+	checkFindings(t, "rule-attributes", `
+java_library(
+    name = "foo",
+    srcs = "a.java",
+    neverlink = 1,
+    javacopts = select({"//conditions:default": []}),
+)
+
+cc_test(
+    name = "foo_test",
+    sizes = "small",
+    shard_count = "3",
+    local = True,
+    tags = ["manual"],
+)
+
+genrule(
+    name = "gen",
+    cmd = "touch $@",
+)
+
+genrule(**kwargs)
+
+genrule(*args)
+
+genrule("gen2", srcs = "gen2.in")
+`,
+		[]string{
+			`:3: The attribute "srcs" of "java_library" should be of type list, not string.`,
+			`:10: Rule "cc_test" has no attribute "sizes".`,
+			`:11: The attribute "shard_count" of "cc_test" should be of type integer, not string.`,
+			`:16: Missing mandatory attribute "outs" of "genrule".`,
+			`:25: The attribute "srcs" of "genrule" should be of type list, not string.`,
+		},
+		scopeEverywhere)
+
+	checkFindings(t, "rule-attributes", `
+load(":defs.bzl", "java_library")
+
+def macro(name, cc_library):
+    native.java_library(name = name, src = "a.java")
+    native.cc_library(hdrs = ["a.h"])
+    java_library(src = "a.java")
+    cc_library(src = "a.java")
+`,
+		[]string{
+			`:4: Rule "java_library" has no attribute "src".`,
+			`:5: Missing mandatory attribute "name" of "cc_library".`,
+		},
+		scopeEverywhere)
+}
+
+func TestRuleAttributesWarningCustomRules(t *testing.T) {
+	checkFindings(t, "rule-attributes", `
+_ATTRS = {
+    "srcs": attr.label_list(allow_files = True),
+    "out": attr.output(mandatory = True),
+    "_tool": attr.label(default = "//tools:gen"),
+}
+
+my_rule = rule(
+    implementation = _impl,
+    attrs = _ATTRS | {"opts": attr.string_list()},
+)
+
+my_test = rule(implementation = _impl, test = True, attrs = dict(_ATTRS, count = attr.int()))
+
+my_rule(name = "a", srcs = "a.txt", out = "a.out", opts = ["x"], _tool = "//x")
+my_test(name = "b", out = "b.out", size = "small", count = "3")
+`,
+		[]string{
+			`:14: The attribute "srcs" of "my_rule" should be of type list, not string.`,
+			`:14: Rule "my_rule" has no attribute "_tool".`,
+			`:15: The attribute "count" of "my_test" should be of type integer, not string.`,
+		},
+		scopeEverywhere)
+}
+
+func TestRuleAttributesWarningMacros(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"test/package/defs.bzl": `
+my_rule = rule(
+    implementation = _impl,
+    attrs = {
+        "srcs": attr.label_list(allow_files = True),
+        "out": attr.output(mandatory = True),
+        "opts": attr.string_list(),
+    },
+)
+
+def my_macro(name, srcs = [], **kwargs):
+    my_rule(name = name, srcs = srcs, out = name + ".out", **kwargs)
+
+def helper(x):
+    return my_rule(name = x)
+`,
+	})()
+
+	checkFindings(t, "rule-attributes", `
+load(":defs.bzl", "helper", "my_macro")
+
+my_macro(name = "c", srcs = "c.txt", opts = [], tags = ["manual"], out = "c")
+my_macro(srcs = [], deps = [])
+my_macro("d", srcs = [])
+helper("e")
+`,
+		[]string{
+			`:3: The attribute "srcs" of "my_macro" should be of type list, not string.`,
+			`:3: Rule "my_macro" has no attribute "out".`,
+			`:4: Rule "my_macro" has no attribute "deps".`,
+			`:4: Missing mandatory attribute "name" of "my_macro".`,
+		},
+		scopeBuild)
+
+	// Calls of macros are only validated in BUILD files, functions without a `name` parameter
+	// are not macros
+	checkFindings(t, "rule-attributes", `
+def real(x):
+    return x
+
+def _macro(name, srcs):
+    native.filegroup(name = name, srcs = srcs)
+
+def other_macro(name):
+    real(a)
+    _macro(name = name, deps = [])
+`,
+		[]string{
+			`:9: Rule "_macro" has no attribute "deps".`,
+			`:9: Missing mandatory attribute "srcs" of "_macro".`,
+		},
+		scopeBuild)
+}
+
+func TestRuleAttributesWarningLoadedRules(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"test/package/defs.bzl": `
+load("//lib:rules.bzl", _lib_rule = "lib_rule")
+
+lib_rule = _lib_rule
+
+def lib_macro(name, visibility = None, *args):
+    _lib_rule(name = name, visibility = visibility)
+`,
+		"lib/rules.bzl": `
+load("//test/package:defs.bzl", "lib_rule")
+
+lib_rule = rule(
+    implementation = _impl,
+    attrs = {"deps": attr.label_list(mandatory = True)},
+)
+`,
+	})()
+
+	checkFindings(t, "rule-attributes", `
+load(":defs.bzl", "lib_rule", "lib_macro")
+
+lib_rule(name = "a", deps = ":b")
+lib_rule(name = "b", dep = [":a"])
+lib_macro(name = "c", deps = [])
+`,
+		[]string{
+			`:3: The attribute "deps" of "lib_rule" should be of type list, not string.`,
+			`:4: Rule "lib_rule" has no attribute "dep".`,
+			`:4: Missing mandatory attribute "deps" of "lib_rule".`,
+		},
+		scopeEverywhere)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Warnings about calls of Bazel builtin functions
+
+package warn
+
+import (
+	"fmt"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/builtins"
+	"github.com/bazelbuild/buildtools/bzlenv"
+	"github.com/bazelbuild/buildtools/types"
+
+	pb "github.com/bazelbuild/buildtools/builtins_proto"
+)
+
+// builtinFunctionName returns the fully qualified name of a called builtin function,
+// e.g. "rule", "attr.label" or "ctx.actions.run", or an empty string if the function
+// is not a builtin.
+func builtinFunctionName(x build.Expr, info *types.Info, env *bzlenv.Environment) string {
+	switch x := x.(type) {
+	case *build.Ident:
+		if env.Get(x.Name) != nil {
+			return ""
+		}
+		return x.Name
+	case *build.DotExpr:
+		switch t := info.TypeOf(x.X); t {
+		case Ctx, CtxActions, CtxActionsArgs:
+			return t.String() + "." + x.Name
+		}
+		if module, ok := x.X.(*build.Ident); ok && env.Get(module.Name) == nil {
+			return module.Name + "." + x.Name
+		}
+	}
+	return ""
+}
+
+// checkBuiltinCall checks the arguments of a call against the signature of a builtin function.
+func checkBuiltinCall(call *build.CallExpr, name string, fn *pb.Function) []*LinterFinding {
+	var findings []*LinterFinding
+
+	var positional []*pb.Param
+	for _, param := range fn.Param {
+		if param.Positional {
+			positional = append(positional, param)
+		}
+	}
+
+	passed := make(map[string]bool)
+	unpacked := false // whether the call contains *args or **kwargs
+	for _, arg := range call.List {
+		if _, ok := arg.(*build.AssignExpr); !ok {
+			if unary, ok := arg.(*build.UnaryExpr); ok && (unary.Op == "*" || unary.Op == "**") {
+				unpacked = true
+			}
+		}
+	}
+	i := 0
+	for _, arg := range call.List {
+		if as, ok := arg.(*build.AssignExpr); ok {
+			if key, ok := as.LHS.(*build.Ident); ok {
+				passed[key.Name] = true
+			}
+		} else if _, ok := arg.(*build.UnaryExpr); !ok && i < len(positional) {
+			passed[positional[i].Name] = true
+			i++
+		}
+	}
+
+	for j, arg := range call.List {
+		as, ok := arg.(*build.AssignExpr)
+		if !ok {
+			continue
+		}
+		key, ok := as.LHS.(*build.Ident)
+		if !ok {
+			continue
+		}
+		param := builtins.Param(fn, key.Name)
+		if param == nil {
+			if !fn.Kwargs {
+				findings = append(findings, makeLinterFinding(key,
+					fmt.Sprintf("Function %q has no parameter %q.", name, key.Name)))
+			}
+			continue
+		}
+		if param.Deprecation == nil {
+			continue
+		}
+		message := fmt.Sprintf("The parameter %q of %q is deprecated.", key.Name, name)
+		if param.Deprecation.Message != "" {
+			message += " " + param.Deprecation.Message
+		}
+		newName := param.Deprecation.RenamedTo
+		if newName == "" || passed[newName] {
+			findings = append(findings, makeLinterFinding(key, message))
+			continue
+		}
+		newKey := *key
+		newKey.Name = newName
+		newArg := *as
+		newArg.LHS = &newKey
+		findings = append(findings, makeLinterFinding(key, message,
+			LinterReplacement{&call.List[j], &newArg}))
+	}
+
+	if unpacked {
+		return findings
+	}
+	for _, param := range fn.Param {
+		if param.Required && !passed[param.Name] {
+			findings = append(findings, makeLinterFinding(call,
+				fmt.Sprintf("Missing required argument %q of %q.", param.Name, name)))
+		}
+	}
+	return findings
+}
+
+func builtinArgsWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
+	var findings []*LinterFinding
+	info := fileReader.typeInfo(f)
+
+	var walk func(e *build.Expr, env *bzlenv.Environment)
+	walk = func(e *build.Expr, env *bzlenv.Environment) {
+		defer bzlenv.WalkOnceWithEnvironment(*e, env, walk)
+
+		call, ok := (*e).(*build.CallExpr)
+		if !ok {
+			return
+		}
+		name := builtinFunctionName(call.X, info, env)
+		if name == "" {
+			return
+		}
+		if fn := builtins.Lookup(name); fn != nil {
+			findings = append(findings, checkBuiltinCall(call, name, fn)...)
+		}
+	}
+	var expr build.Expr = f
+	walk(&expr, bzlenv.NewEnvironment())
+
+	return findings
+}
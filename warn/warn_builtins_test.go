@@ -0,0 +1,104 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warn
+
+import "testing"
+
+func TestBuiltinArgsUnknown(t *testing.T) {
+	checkFindings(t, "builtin-args", `
+def _impl(ctx):
+    ctx.actions.run(
+        outputs = [out],
+        executable = tool,
+        argumnets = ["--foo"],
+    )
+    ctx.actions.write(out, "content", executable = True)
+    struct(foo = "bar")
+
+foo = rule(
+    implementation = _impl,
+    attr = {
+        "srcs": attr.label_list(alow_files = True),
+        "dep": attr.label(default = "//foo"),
+    },
+)
+
+srcs = sorted(dict(foo = "bar").keys(), reversed = True)
+`,
+		[]string{
+			`:5: Function "ctx.actions.run" has no parameter "argumnets".`,
+			`:7: Function "ctx.actions.write" has no parameter "executable".`,
+			`:12: Function "rule" has no parameter "attr".`,
+			`:13: Function "attr.label_list" has no parameter "alow_files".`,
+			`:18: Function "sorted" has no parameter "reversed".`,
+		},
+		scopeEverywhere)
+}
+
+func TestBuiltinArgsMissing(t *testing.T) {
+	checkFindings(t, "builtin-args", `
+def _impl(ctx):
+    ctx.actions.run(outputs = [out])
+    ctx.actions.write(out)
+    ctx.actions.write(out, "content")
+    ctx.actions.write(*args)
+    ctx.actions.run(**kwargs)
+
+foo = rule()
+bar = rule(_impl)
+`,
+		[]string{
+			`:2: Missing required argument "executable" of "ctx.actions.run".`,
+			`:3: Missing required argument "content" of "ctx.actions.write".`,
+			`:8: Missing required argument "implementation" of "rule".`,
+		},
+		scopeEverywhere)
+}
+
+func TestBuiltinArgsShadowed(t *testing.T) {
+	checkFindings(t, "builtin-args", `
+load(":rules.bzl", "rule")
+
+def f(attr, actions):
+    rule(foo = "bar")
+    attr.label(foo = "bar")
+    actions.run(foo = "bar")
+`,
+		[]string{},
+		scopeEverywhere)
+}
+
+func TestBuiltinArgsDeprecated(t *testing.T) {
+	checkFindingsAndFix(t, "builtin-args", `
+attr.label(single_file = True)
+attr.label(single_file = True, allow_single_file = True)
+attr.label_list(non_empty = True)
+repository_rule(_impl, environ = ["FOO"])
+`, `
+attr.label(allow_single_file = True)
+attr.label(single_file = True, allow_single_file = True)
+attr.label_list(non_empty = True)
+repository_rule(_impl, environ = ["FOO"])
+`,
+		[]string{
+			`:1: The parameter "single_file" of "attr.label" is deprecated. Use 'allow_single_file' instead.`,
+			`:2: The parameter "single_file" of "attr.label" is deprecated. Use 'allow_single_file' instead.`,
+			`:3: The parameter "non_empty" of "attr.label_list" is deprecated. Use 'allow_empty = False' instead.`,
+			`:4: The parameter "environ" of "repository_rule" is deprecated. Use 'repository_ctx.getenv' instead.`,
+		},
+		scopeEverywhere)
+}
@@ -537,11 +537,11 @@ func unusedVariableWarning(f *build.File) []*LinterFinding {
 	return findings
 }
 
-func redefinedVariableWarning(f *build.File) []*LinterFinding {
+func redefinedVariableWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
 	findings := []*LinterFinding{}
 	definedSymbols := make(map[string]bool)
 
-	types := detectTypes(f)
+	info := fileReader.typeInfo(f)
 	for _, s := range f.Stmt {
 		// look for all assignments in the scope
 		as, ok := s.(*build.AssignExpr)
@@ -557,7 +557,7 @@ func redefinedVariableWarning(f *build.File) []*LinterFinding {
 			continue
 		}
 
-		if as.Op == "+=" && (types[as.LHS] == List || types[as.RHS] == List) {
+		if as.Op == "+=" && (info.TypeOf(as.LHS) == List || info.TypeOf(as.RHS) == List) {
 			// Not a reassignment, just appending to a list
 			continue
 		}
@@ -0,0 +1,291 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Warnings about dependency cycles between targets and packages
+
+package warn
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/labels"
+)
+
+// dependencyAttrs are the attributes whose labels are the edges of the dependency graph.
+var dependencyAttrs = []string{"deps", "runtime_deps", "exports", "data"}
+
+// depEdge is an edge of a dependency graph.
+type depEdge struct {
+	to   string            // the node the edge points to
+	path string            // the path of the BUILD file that declares the edge
+	str  *build.StringExpr // the label that declares the edge
+}
+
+// position returns the position of the edge in the workspace as "path:line".
+func (e depEdge) position() string {
+	start, _ := e.str.Span()
+	return fmt.Sprintf("%s:%d", e.path, start.Line)
+}
+
+// before returns whether the edge is declared before the other one, edges declared in
+// different BUILD files are ordered by their paths.
+func (e depEdge) before(other depEdge) bool {
+	if e.path != other.path {
+		return e.path < other.path
+	}
+	return e.str.Start.Byte < other.str.Start.Byte
+}
+
+// depGraph is a dependency graph of targets declared in BUILD files, the nodes are the
+// labels of the targets. The BUILD files of other packages are read lazily.
+type depGraph struct {
+	f          *build.File
+	fileReader *FileReader
+	current    *packageTargets
+	edges      map[string][]depEdge
+}
+
+func newDepGraph(f *build.File, fileReader *FileReader) *depGraph {
+	return &depGraph{
+		f:          f,
+		fileReader: fileReader,
+		current:    analyzePackage(f, fileReader),
+		edges:      make(map[string][]depEdge),
+	}
+}
+
+// targets returns the targets declared in a package, or nil if its BUILD file can't be read.
+func (g *depGraph) targets(pkg string) *packageTargets {
+	if pkg == g.f.Pkg {
+		return g.current
+	}
+	if g.fileReader == nil {
+		return nil
+	}
+	return g.fileReader.packageTargets(pkg)
+}
+
+// targetEdges returns the dependencies of a target on other rules.
+func (g *depGraph) targetEdges(node string) []depEdge {
+	if edges, ok := g.edges[node]; ok {
+		return edges
+	}
+	var edges []depEdge
+	label := labels.Parse(node)
+	if pt := g.targets(label.Package); pt != nil {
+		if rule, ok := pt.rules[label.Target]; ok {
+			for _, attr := range dependencyAttrs {
+				for _, str := range labelStrings(rule.Attr(attr)) {
+					if strings.ContainsAny(str.Value, "$%{") {
+						continue
+					}
+					dep := g.fileReader.resolveLabel(str.Value, label.Package)
+					if dep.Repository != "" || dep.Target == "" {
+						continue
+					}
+					if depTargets := g.targets(dep.Package); depTargets == nil || depTargets.rules[dep.Target] == nil {
+						// Source files and unknown targets can't be a part of a cycle
+						continue
+					}
+					edges = append(edges, depEdge{dep.Format(), pt.path, str})
+				}
+			}
+		}
+	}
+	g.edges[node] = edges
+	return edges
+}
+
+// packageEdges returns the dependencies of a package on other packages. If several targets
+// of the package depend on the same package, the edge declared first is used.
+func (g *depGraph) packageEdges(pkg string) []depEdge {
+	pt := g.targets(pkg)
+	if pt == nil {
+		return nil
+	}
+	first := make(map[string]depEdge)
+	for name := range pt.rules {
+		for _, edge := range g.targetEdges(labels.Label{Package: pkg, Target: name}.Format()) {
+			depPkg := labels.Parse(edge.to).Package
+			if depPkg == pkg {
+				continue
+			}
+			if e, ok := first[depPkg]; !ok || edge.before(e) {
+				first[depPkg] = depEdge{depPkg, edge.path, edge.str}
+			}
+		}
+	}
+	var edges []depEdge
+	for _, edge := range first {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].to < edges[j].to })
+	return edges
+}
+
+// cyclicComponents returns the strongly connected components of the graph reachable from
+// the root that contain cycles, computed with Tarjan's algorithm.
+func cyclicComponents(root string, successors func(node string) []depEdge) []map[string]bool {
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components []map[string]bool
+
+	var visit func(node string)
+	visit = func(node string) {
+		index[node] = len(index)
+		lowlink[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+
+		selfLoop := false
+		for _, edge := range successors(node) {
+			if edge.to == node {
+				selfLoop = true
+			}
+			if _, ok := index[edge.to]; !ok {
+				visit(edge.to)
+				if lowlink[edge.to] < lowlink[node] {
+					lowlink[node] = lowlink[edge.to]
+				}
+			} else if onStack[edge.to] && index[edge.to] < lowlink[node] {
+				lowlink[node] = index[edge.to]
+			}
+		}
+
+		if lowlink[node] != index[node] {
+			return
+		}
+		component := make(map[string]bool)
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component[last] = true
+			if last == node {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoop {
+			components = append(components, component)
+		}
+	}
+	visit(root)
+	return components
+}
+
+// shortestCycle returns the edges of the shortest cycle that starts and ends at the given
+// node and doesn't leave its strongly connected component.
+func shortestCycle(start string, component map[string]bool, successors func(node string) []depEdge) []depEdge {
+	type step struct {
+		prev string
+		edge depEdge
+	}
+	reached := make(map[string]step)
+	queue := []string{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, edge := range successors(node) {
+			if !component[edge.to] {
+				continue
+			}
+			if _, ok := reached[edge.to]; ok {
+				continue
+			}
+			reached[edge.to] = step{node, edge}
+			if edge.to == start {
+				var cycle []depEdge
+				for n := start; ; {
+					s := reached[n]
+					cycle = append([]depEdge{s.edge}, cycle...)
+					n = s.prev
+					if n == start {
+						return cycle
+					}
+				}
+			}
+			queue = append(queue, edge.to)
+		}
+	}
+	return nil
+}
+
+// formatCycle formats a cycle starting at the given node, every edge is followed by its position.
+func formatCycle(start string, cycle []depEdge) string {
+	parts := []string{start}
+	for _, edge := range cycle {
+		parts = append(parts, fmt.Sprintf("%s (%s)", edge.to, edge.position()))
+	}
+	return strings.Join(parts, " -> ")
+}
+
+func dependencyCycleWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
+	if f.Type != build.TypeBuild {
+		return nil
+	}
+
+	var findings []*LinterFinding
+	g := newDepGraph(f, fileReader)
+	reported := make(map[string]bool) // nodes of already reported components
+	for _, rule := range f.Rules("") {
+		name := rule.ExplicitName()
+		if _, ok := g.current.rules[name]; !ok {
+			continue
+		}
+		node := labels.Label{Package: f.Pkg, Target: name}.Format()
+		if reported[node] {
+			continue
+		}
+		for _, component := range cyclicComponents(node, g.targetEdges) {
+			if !component[node] {
+				continue
+			}
+			for n := range component {
+				reported[n] = true
+			}
+			cycle := shortestCycle(node, component, g.targetEdges)
+			findings = append(findings, makeLinterFinding(cycle[0].str,
+				fmt.Sprintf("Dependency cycle: %s.", formatCycle(node, cycle))))
+		}
+	}
+	return findings
+}
+
+func packageCycleWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
+	if f.Type != build.TypeBuild || fileReader == nil {
+		return nil
+	}
+
+	g := newDepGraph(f, fileReader)
+	for _, component := range cyclicComponents(f.Pkg, g.packageEdges) {
+		if !component[f.Pkg] {
+			continue
+		}
+		cycle := shortestCycle(f.Pkg, component, g.packageEdges)
+		start := "//" + f.Pkg
+		for i := range cycle {
+			cycle[i].to = "//" + cycle[i].to
+		}
+		return []*LinterFinding{makeLinterFinding(cycle[0].str,
+			fmt.Sprintf("Package dependency cycle: %s.", formatCycle(start, cycle)))}
+	}
+	return nil
+}
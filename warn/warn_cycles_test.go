@@ -0,0 +1,129 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warn
+
+import (
+	"testing"
+
+	"github.com/bazelbuild/buildtools/build"
+)
+
+func TestDependencyCycleSamePackage(t *testing.T) {
+	checkFindings(t, "dependency-cycle", `
+cc_library(
+    name = "a",
+    deps = [":b"],
+)
+
+cc_library(
+    name = "b",
+    deps = [
+        ":c",
+        "a.h",
+    ],
+)
+
+cc_library(
+    name = "c",
+    data = [":a"],
+)
+
+cc_library(
+    name = "d",
+    deps = [":d", ":a"],
+)
+`,
+		[]string{
+			`:3: Dependency cycle: //test/package:a -> //test/package:b (test/package/BUILD:3) -> //test/package:c (test/package/BUILD:9) -> //test/package:a (test/package/BUILD:16).`,
+			`:21: Dependency cycle: //test/package:d -> //test/package:d (test/package/BUILD:21).`,
+		},
+		scopeBuild)
+}
+
+func TestDependencyCycleAcrossPackages(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"lib/BUILD": `
+java_library(
+    name = "lib",
+    runtime_deps = ["//test/package:bin"],
+)
+
+java_library(
+    name = "other",
+    exports = ["//util"],
+)
+`,
+		"util/BUILD": `
+java_library(
+    name = "util",
+    deps = ["//test/package:util_user"],
+)
+`,
+	})()
+
+	checkFindings(t, "dependency-cycle", `
+java_binary(
+    name = "bin",
+    deps = ["//lib"],
+)
+
+java_library(
+    name = "util_user",
+    deps = ["//lib:other"],
+)
+`,
+		[]string{
+			`:3: Dependency cycle: //test/package:bin -> //lib (test/package/BUILD:3) -> //test/package:bin (lib/BUILD:4).`,
+			`:8: Dependency cycle: //test/package:util_user -> //lib:other (test/package/BUILD:8) -> //util (lib/BUILD:9) -> //test/package:util_user (util/BUILD:4).`,
+		},
+		scopeBuild)
+
+	checkFindings(t, "package-cycle", `
+java_binary(
+    name = "bin",
+)
+
+java_library(
+    name = "util_user",
+    deps = ["//lib:other"],
+)
+`,
+		[]string{
+			`:7: Package dependency cycle: //test/package -> //lib (test/package/BUILD:7) -> //test/package (lib/BUILD:4).`,
+		},
+		scopeBuild)
+}
+
+func TestDepEdgeBefore(t *testing.T) {
+	str := func(offset int) *build.StringExpr {
+		return &build.StringExpr{Start: build.Position{Byte: offset}}
+	}
+	tests := []struct {
+		a, b depEdge
+		want bool
+	}{
+		{depEdge{"//a", "a/BUILD", str(10)}, depEdge{"//b", "a/BUILD", str(20)}, true},
+		{depEdge{"//a", "a/BUILD", str(20)}, depEdge{"//b", "a/BUILD", str(10)}, false},
+		{depEdge{"//a", "a/BUILD", str(20)}, depEdge{"//b", "b/BUILD", str(10)}, true},
+		{depEdge{"//a", "b/BUILD", str(10)}, depEdge{"//b", "a/BUILD", str(20)}, false},
+	}
+	for _, tc := range tests {
+		if got := tc.a.before(tc.b); got != tc.want {
+			t.Errorf("%v.before(%v) = %t, want %t", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -20,34 +20,229 @@ package warn
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/bzlenv"
 	"github.com/bazelbuild/buildtools/labels"
 )
 
-func checkDeprecatedFunction(stmt build.Expr, loadedSymbols *map[string]*build.Ident, fullLabel string) *LinterFinding {
-	def, ok := stmt.(*build.DefStmt)
-	if !ok {
+// deprecationReplacementRegex matches structured deprecation notes like
+// "use //foo:bar.bzl%new_function".
+var deprecationReplacementRegex = regexp.MustCompile(`(?i)(?:^|\s)use\s+(\S+\.bzl)%([A-Za-z_][A-Za-z0-9_]*)`)
+
+// deprecatedSymbol describes a deprecated symbol of a .bzl file.
+type deprecatedSymbol struct {
+	kind        string        // e.g. "function" or "rule"
+	name        string        // the name of the symbol in the file that defines it
+	file        *build.File   // the file that defines the symbol
+	replacement *symbolOrigin // the symbol that should be used instead, if known
+}
+
+// deprecationNote returns the text of a "Deprecated:" section of a docstring or a comment,
+// i.e. the rest of the line and the following lines that are indented deeper.
+func deprecationNote(text string) (string, bool) {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \r")
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "Deprecated:") {
+			continue
+		}
+		indent := countLeadingSpaces(line)
+		note := []string{strings.TrimPrefix(trimmed, "Deprecated:")}
+		for _, next := range lines[i+1:] {
+			if strings.TrimSpace(next) == "" || countLeadingSpaces(next) <= indent {
+				break
+			}
+			note = append(note, strings.TrimSpace(next))
+		}
+		return strings.TrimSpace(strings.Join(note, " ")), true
+	}
+	return "", false
+}
+
+// commentText returns the text of the comments before a statement without the "#" markers.
+func commentText(stmt build.Expr) string {
+	var lines []string
+	for _, c := range stmt.Comment().Before {
+		line := strings.TrimPrefix(c.Token, "#")
+		lines = append(lines, strings.TrimPrefix(line, " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// statementDeprecation returns the deprecation note of a top-level statement that defines
+// a symbol: the docstring of a function, the `doc` argument of a rule, provider, etc.,
+// or the comments before the statement.
+func statementDeprecation(stmt build.Expr) (string, bool) {
+	switch stmt := stmt.(type) {
+	case *build.DefStmt:
+		if docstring, ok := getDocstring(stmt.Body); ok {
+			if str, ok := (*docstring).(*build.StringExpr); ok {
+				if note, ok := deprecationNote(str.Value); ok {
+					return note, true
+				}
+			}
+		}
+	case *build.AssignExpr:
+		if call, ok := stmt.RHS.(*build.CallExpr); ok {
+			for _, arg := range call.List {
+				if kwarg, ok := arg.(*build.AssignExpr); ok {
+					if key, ok := kwarg.LHS.(*build.Ident); ok && key.Name == "doc" {
+						if str, ok := kwarg.RHS.(*build.StringExpr); ok {
+							if note, ok := deprecationNote(str.Value); ok {
+								return note, true
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return deprecationNote(commentText(stmt))
+}
+
+// deprecation returns the deprecated symbol that a symbol loaded from a .bzl file refers to,
+// following re-exports of symbols loaded from other files. Returns nil if the symbol is not
+// deprecated or can't be found.
+func (fr *FileReader) deprecation(label labels.Label, name string, visited map[symbolOrigin]bool) *deprecatedSymbol {
+	origin := symbolOrigin{label, name}
+	if visited[origin] || label.Repository != "" || label.Target == "" {
 		return nil
 	}
-	node, ok := (*loadedSymbols)[def.Name]
-	if !ok {
+	visited[origin] = true
+
+	f := fr.GetFile(label.Package, label.Target)
+	if f == nil {
 		return nil
 	}
-	docstring, ok := getDocstring(def.Body)
-	if !ok {
+	for _, stmt := range f.Stmt {
+		var kind string
+		var rhs build.Expr
+		switch stmt := stmt.(type) {
+		case *build.DefStmt:
+			if stmt.Name != name {
+				continue
+			}
+			kind = "function"
+		case *build.AssignExpr:
+			if lhs, ok := stmt.LHS.(*build.Ident); !ok || lhs.Name != name {
+				continue
+			}
+			kind = symbolKind(stmt.RHS)
+			rhs = stmt.RHS
+		default:
+			continue
+		}
+
+		if note, ok := statementDeprecation(stmt); ok {
+			symbol := &deprecatedSymbol{kind: kind, name: name, file: f}
+			if m := deprecationReplacementRegex.FindStringSubmatch(note); m != nil {
+				symbol.replacement = &symbolOrigin{labels.ParseRelative(m[1], f.Pkg), m[2]}
+			}
+			return symbol
+		}
+
+		// Re-exports of loaded symbols
+		ident, ok := rhs.(*build.Ident)
+		if !ok {
+			return nil
+		}
+		for _, s := range f.Stmt {
+			load, ok := s.(*build.LoadStmt)
+			if !ok {
+				continue
+			}
+			for i, to := range load.To {
+				if to.Name == ident.Name {
+					return fr.deprecation(labels.ParseRelative(load.Module.Value, f.Pkg), load.From[i].Name, visited)
+				}
+			}
+		}
 		return nil
 	}
-	str, ok := (*docstring).(*build.StringExpr)
+	return nil
+}
+
+// deprecatedReferences returns the pointers to the references of a loaded symbol that should
+// be renamed, or false if the new name would refer to a different symbol at any of them.
+func deprecatedReferences(f *build.File, to *build.Ident, newName string) ([]*build.Expr, bool) {
+	topLevel := make(map[*build.Expr]bool)
+	for i := range f.Stmt {
+		topLevel[&f.Stmt[i]] = true
+	}
+	var refs []*build.Expr
+	valid := true
+	var walk func(e *build.Expr, env *bzlenv.Environment)
+	walk = func(e *build.Expr, env *bzlenv.Environment) {
+		defer walkOnceSkippingKeywords(*e, env, walk)
+
+		ident, ok := (*e).(*build.Ident)
+		if !ok || ident.Name != to.Name {
+			return
+		}
+		if binding := env.Get(ident.Name); binding == nil || binding.Definition != to {
+			return
+		}
+		if other := env.Get(newName); other != nil && other.Kind != bzlenv.Imported {
+			// The new name is shadowed here
+			valid = false
+		}
+		if !topLevel[e] {
+			refs = append(refs, e)
+		}
+	}
+	var expr build.Expr = f
+	walk(&expr, bzlenv.NewEnvironment())
+	return refs, valid
+}
+
+// walkOnceSkippingKeywords is a wrapper for bzlenv.WalkOnceWithEnvironment which skips the
+// names of keyword arguments of function calls, e.g. for `foo(x, y = z)` it visits `foo`, `x`,
+// and `z`, because renaming `y` would change the call.
+func walkOnceSkippingKeywords(node build.Expr, env *bzlenv.Environment, fct func(e *build.Expr, env *bzlenv.Environment)) {
+	call, ok := node.(*build.CallExpr)
 	if !ok {
-		return nil
+		bzlenv.WalkOnceWithEnvironment(node, env, fct)
+		return
 	}
-	docstringInfo := parseFunctionDocstring(str)
-	if !docstringInfo.deprecated {
-		return nil
+	fct(&call.X, env)
+	for i := range call.List {
+		if as, ok := call.List[i].(*build.AssignExpr); ok {
+			fct(&as.RHS, env)
+		} else {
+			fct(&call.List[i], env)
+		}
 	}
+}
 
-	return makeLinterFinding(node, fmt.Sprintf("The function %q defined in %q is deprecated.", def.Name, fullLabel))
+// canRename checks whether a loaded symbol can be replaced with a symbol loaded from another
+// file: the new name shouldn't already be defined at the top level, unless it's the same symbol.
+func canRename(f *build.File, replacement symbolOrigin) bool {
+	for _, stmt := range f.Stmt {
+		switch stmt := stmt.(type) {
+		case *build.LoadStmt:
+			for i, to := range stmt.To {
+				if to.Name == replacement.name &&
+					(labels.ParseRelative(stmt.Module.Value, f.Pkg) != replacement.label || stmt.From[i].Name != replacement.name) {
+					return false
+				}
+			}
+		case *build.DefStmt:
+			if stmt.Name == replacement.name {
+				return false
+			}
+		case *build.AssignExpr:
+			for _, ident := range bzlenv.CollectLValues(stmt.LHS) {
+				if ident.Name == replacement.name {
+					return false
+				}
+			}
+		}
+	}
+	return true
 }
 
 func deprecatedFunctionWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
@@ -56,6 +251,13 @@ func deprecatedFunctionWarning(f *build.File, fileReader *FileReader) []*LinterF
 	}
 
 	findings := []*LinterFinding{}
+	var fixable *LinterFinding // the finding the combined fix is attached to
+	var renames []LinterReplacement
+	lr := &loadRewriter{
+		f:        f,
+		newLoads: make(map[*build.LoadStmt]*build.LoadStmt),
+		after:    make(map[labels.Label]*build.LoadStmt),
+	}
 	for _, stmt := range f.Stmt {
 		load, ok := stmt.(*build.LoadStmt)
 		if !ok {
@@ -65,21 +267,42 @@ func deprecatedFunctionWarning(f *build.File, fileReader *FileReader) []*LinterF
 		if label.Repository != "" || label.Target == "" {
 			continue
 		}
-		loadedFile := fileReader.GetFile(label.Package, label.Target)
-		if loadedFile == nil {
-			continue
-		}
-		loadedSymbols := make(map[string]*build.Ident)
-		for _, from := range load.From {
-			loadedSymbols[from.Name] = from
-		}
+		for i, from := range load.From {
+			symbol := fileReader.deprecation(label, from.Name, make(map[symbolOrigin]bool))
+			if symbol == nil {
+				continue
+			}
+			message := fmt.Sprintf("The %s %q defined in %q is deprecated.", symbol.kind, symbol.name, symbol.file.CanonicalPath())
+			finding := makeLinterFinding(from, message)
+			findings = append(findings, finding)
 
-		for _, stmt := range loadedFile.Stmt {
-			if finding := checkDeprecatedFunction(stmt, &loadedSymbols, loadedFile.CanonicalPath()); finding != nil {
-				findings = append(findings, finding)
+			r := symbol.replacement
+			if r == nil {
+				continue
+			}
+			finding.Message += fmt.Sprintf(" Please use %q from %q instead.", r.name, r.label.Format())
+			if !canRename(f, *r) {
+				continue
+			}
+			to := load.To[i]
+			refs, ok := deprecatedReferences(f, to, r.name)
+			if !ok {
+				continue
+			}
+			for _, ref := range refs {
+				ident := *(*ref).(*build.Ident)
+				ident.Name = r.name
+				renames = append(renames, LinterReplacement{ref, &ident})
+			}
+			lr.move(load, to, *r, r.name)
+			if fixable == nil {
+				fixable = finding
 			}
 		}
-
+	}
+	if fixable != nil {
+		// Individual replacements can't be combined together, attach all of them to one finding
+		fixable.Replacement = append(renames, lr.replacements()...)
 	}
 	return findings
 }
@@ -88,3 +88,143 @@ load(":nonexistent.bzl", "foo", "bar", "baz")
 		[]string{},
 		scopeEverywhere)
 }
+
+func TestDeprecatedSymbols(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"test/package/foo.bzl": `
+load("//lib:impl.bzl", _old_macro = "old_macro", _new_macro = "new_macro")
+
+my_rule = rule(
+    implementation = _impl,
+    doc = """My rule.
+
+    Deprecated:
+      please use other_rule instead.
+    """,
+)
+
+MyInfo = provider(doc = "Deprecated: use the OtherInfo provider.")
+
+# Deprecated: the list is no longer maintained.
+MY_CONSTANT = ["a", "b"]
+
+# A constant that is still fine.
+OTHER_CONSTANT = 1
+
+old_macro = _old_macro
+new_macro = _new_macro
+`,
+		"lib/impl.bzl": `
+def old_macro(name):
+    """Creates targets.
+
+    Deprecated: use //lib:new.bzl%new_macro
+    """
+    pass
+
+def new_macro(name):
+    pass
+`,
+	})()
+
+	checkFindings(t, "deprecated-function", `
+load(":foo.bzl", "MY_CONSTANT", "MyInfo", "OTHER_CONSTANT", "my_rule", "new_macro", "old_macro")
+`,
+		[]string{
+			`:1: The constant "MY_CONSTANT" defined in "//test/package/foo.bzl" is deprecated.`,
+			`:1: The provider "MyInfo" defined in "//test/package/foo.bzl" is deprecated.`,
+			`:1: The rule "my_rule" defined in "//test/package/foo.bzl" is deprecated.`,
+			`:1: The function "old_macro" defined in "//lib/impl.bzl" is deprecated. Please use "new_macro" from "//lib:new.bzl" instead.`,
+		},
+		scopeEverywhere)
+}
+
+func TestDeprecatedFunctionReplacement(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"test/package/foo.bzl": `
+def old_function(x):
+    """Does something.
+
+    Deprecated: use //lib:new.bzl%new_function
+    """
+    pass
+
+def other():
+    pass
+`,
+		"lib/BUILD": ``,
+	})()
+
+	checkFindingsAndFix(t, "deprecated-function", `
+load(":foo.bzl", "old_function", "other")
+
+def f():
+    old_function(1)
+    other()
+
+old_function(2)
+`, `
+load(":foo.bzl", "other")
+load("//lib:new.bzl", "new_function")
+
+def f():
+    new_function(1)
+    other()
+
+new_function(2)
+`,
+		[]string{
+			`:1: The function "old_function" defined in "//test/package/foo.bzl" is deprecated. Please use "new_function" from "//lib:new.bzl" instead.`,
+		},
+		scopeEverywhere)
+
+	// Keyword argument names are not references
+	checkFindingsAndFix(t, "deprecated-function", `
+load(":foo.bzl", "old_function")
+
+api = struct(old_function = old_function)
+foo(old_function = 1)
+`, `
+load("//lib:new.bzl", "new_function")
+
+api = struct(old_function = new_function)
+foo(old_function = 1)
+`,
+		[]string{
+			`:1: The function "old_function" defined in "//test/package/foo.bzl" is deprecated. Please use "new_function" from "//lib:new.bzl" instead.`,
+		},
+		scopeEverywhere)
+
+	// The new name is already used, no automatic fix
+	checkFindingsAndFix(t, "deprecated-function", `
+load(":foo.bzl", "old_function")
+
+def f(new_function):
+    old_function(new_function)
+`, `
+load(":foo.bzl", "old_function")
+
+def f(new_function):
+    old_function(new_function)
+`,
+		[]string{
+			`:1: The function "old_function" defined in "//test/package/foo.bzl" is deprecated. Please use "new_function" from "//lib:new.bzl" instead.`,
+		},
+		scopeEverywhere)
+
+	// The new function is already loaded
+	checkFindingsAndFix(t, "deprecated-function", `
+load(":foo.bzl", "old_function")
+load("//lib:new.bzl", "new_function")
+
+old_function(new_function)
+`, `
+load("//lib:new.bzl", "new_function")
+
+new_function(new_function)
+`,
+		[]string{
+			`:1: The function "old_function" defined in "//test/package/foo.bzl" is deprecated. Please use "new_function" from "//lib:new.bzl" instead.`,
+		},
+		scopeEverywhere)
+}
@@ -86,7 +86,6 @@ type docstringInfo struct {
 	hasHeader    bool                      // whether the docstring has a one-line header
 	args         map[string]build.Position // map of documented arguments, the values are line numbers
 	returns      bool                      // whether the return value is documented
-	deprecated   bool                      // whether the function is marked as deprecated
 	argumentsPos build.Position            // line of the `Arguments:` block (not `Args:`), if it exists
 }
 
@@ -158,7 +157,6 @@ func parseFunctionDocstring(doc *build.StringExpr) docstringInfo {
 			continue
 		case prefix + "Deprecated:":
 			isArgumentsDescription = false
-			info.deprecated = true
 			continue
 		}
 
@@ -215,11 +213,290 @@ func isDocstringRequired(def *build.DefStmt) bool {
 	return !strings.HasPrefix(def.Name, "_") && stmtsCount(def.Body) >= FunctionLengthDocstringThreshold
 }
 
+// Placeholders for the descriptions inserted by the automatic fixes
+const (
+	functionDescriptionPlaceholder = "TODO: Describe the function."
+	argumentDescriptionPlaceholder = "TODO: Describe the argument."
+	returnDescriptionPlaceholder   = "TODO: Describe the return value."
+)
+
+// paramNames returns the names of the function parameters in the order they're defined,
+// *args and **kwargs are prefixed with asterisks.
+func paramNames(def *build.DefStmt) []string {
+	var names []string
+	for _, param := range def.Params {
+		name, op := build.GetParamName(param)
+		if name == "" {
+			continue
+		}
+		names = append(names, op+name)
+	}
+	return names
+}
+
+// docstringToken returns a triple-quoted token for a docstring value if it doesn't need escaping,
+// so that the docstring can be formatted like the other docstrings of the file.
+func docstringToken(value string) string {
+	token := `"""` + value + `"""`
+	if s, triple, err := build.Unquote(token); err != nil || !triple || s != value {
+		return ""
+	}
+	return token
+}
+
+// newFunctionDocstring returns a Google-style docstring skeleton for a function.
+func newFunctionDocstring(def *build.DefStmt) *build.StringExpr {
+	start, _ := def.Span()
+	prefix := strings.Repeat(" ", start.LineRune-1+4)
+	// The position is used for calculating the indentation by other docstring warnings
+	pos := build.Position{
+		Line:     def.ColonPos.Line,
+		LineRune: len(prefix) + 1,
+		Byte:     def.ColonPos.Byte,
+	}
+	lines := []string{functionDescriptionPlaceholder}
+	if params := paramNames(def); len(params) > 0 {
+		lines = append(lines, "", prefix+"Args:")
+		for _, name := range params {
+			lines = append(lines, prefix+"  "+name+": "+argumentDescriptionPlaceholder)
+		}
+	}
+	if hasReturnValues(def) {
+		lines = append(lines, "", prefix+"Returns:", prefix+"  "+returnDescriptionPlaceholder)
+	}
+	lines = append(lines, prefix)
+	value := strings.Join(lines, "\n")
+	return &build.StringExpr{
+		Start:       pos,
+		Value:       value,
+		TripleQuote: true,
+		End:         pos,
+		Token:       docstringToken(value),
+	}
+}
+
+// docstringLines is a function docstring split into lines, used for fixing the docstring
+// while preserving its layout.
+type docstringLines struct {
+	lines  []string
+	prefix string // the indentation of the opening quotes, also used for section headers
+}
+
+func newDocstringLines(doc *build.StringExpr) *docstringLines {
+	start, _ := doc.Span()
+	return &docstringLines{
+		lines:  strings.Split(doc.Value, "\n"),
+		prefix: strings.Repeat(" ", start.LineRune-1),
+	}
+}
+
+// isBlank returns whether a line of a docstring is empty or consists of whitespaces only.
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// isHeader returns whether the i-th line is a header of one of the given sections.
+func (d *docstringLines) isHeader(i int, headers ...string) bool {
+	line := strings.TrimRight(d.lines[i], " \r")
+	for _, header := range headers {
+		if line == d.prefix+header {
+			return true
+		}
+	}
+	return false
+}
+
+// findSection returns the index of the header line of the first of the given sections, or -1.
+func (d *docstringLines) findSection(headers ...string) int {
+	for i := range d.lines {
+		if d.isHeader(i, headers...) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sectionEnd returns the index of the line after the last non-blank line of the section
+// which header is on the given line.
+func (d *docstringLines) sectionEnd(header int) int {
+	end := header + 1
+	for i := header + 1; i < len(d.lines); i++ {
+		if isBlank(d.lines[i]) {
+			continue
+		}
+		if countLeadingSpaces(d.lines[i]) <= len(d.prefix) {
+			break
+		}
+		end = i + 1
+	}
+	return end
+}
+
+// replace replaces the lines from `start` to `end` with new lines.
+func (d *docstringLines) replace(start, end int, lines ...string) {
+	newLines := append([]string{}, d.lines[:start]...)
+	newLines = append(newLines, lines...)
+	d.lines = append(newLines, d.lines[end:]...)
+}
+
+// insertSection inserts a new section before the first of the given sections, or to the end
+// of the docstring if there are none of them. Sections are separated by blank lines.
+func (d *docstringLines) insertSection(section []string, before ...string) {
+	if i := d.findSection(before...); i >= 0 {
+		section = append(section, "")
+		if i > 0 && !isBlank(d.lines[i-1]) {
+			section = append([]string{""}, section...)
+		}
+		d.replace(i, i, section...)
+		return
+	}
+
+	// The closing quotes should be on a separate line
+	end := len(d.lines) - 1
+	if len(d.lines) == 1 || !isBlank(d.lines[end]) {
+		d.lines = append(d.lines, d.prefix)
+		end = len(d.lines) - 1
+	}
+	if !isBlank(d.lines[end-1]) {
+		section = append([]string{""}, section...)
+	}
+	d.replace(end, end, section...)
+}
+
+// stringExpr returns a copy of the docstring with the modified value.
+func (d *docstringLines) stringExpr(doc *build.StringExpr) *build.StringExpr {
+	newDoc := *doc
+	newDoc.Value = strings.Join(d.lines, "\n")
+	newDoc.Token = docstringToken(newDoc.Value)
+	newDoc.TripleQuote = true
+	return &newDoc
+}
+
+// docstringArgument is an entry of the "Args:" section of a docstring.
+type docstringArgument struct {
+	name  string
+	lines []string // the first line and the continuation lines
+}
+
+// fixDocstringArgs returns the docstring with the "Args:" section matching the function
+// signature: the missing arguments are added in the order of the parameters (if `addMissing`
+// is true), the arguments that don't exist are removed, and "Arguments:" is renamed to "Args:".
+func fixDocstringArgs(doc *build.StringExpr, def *build.DefStmt, addMissing bool) *build.StringExpr {
+	d := newDocstringLines(doc)
+	params := paramNames(def)
+	isParam := make(map[string]bool)
+	for _, name := range params {
+		isParam[name] = true
+	}
+
+	header := d.findSection("Args:", "Arguments:")
+	var preamble []string // the lines of the section before the first argument
+	var args []*docstringArgument
+	end := header
+	if header >= 0 {
+		end = d.sectionEnd(header)
+		argIndentation := -1
+		for _, line := range d.lines[header+1 : end] {
+			indentation := countLeadingSpaces(line)
+			if !isBlank(line) && (argIndentation < 0 || indentation <= argIndentation) {
+				if result := argRegex.FindStringSubmatch(line); len(result) > 1 {
+					argIndentation = indentation
+					args = append(args, &docstringArgument{result[1], []string{line}})
+					continue
+				}
+			}
+			if len(args) == 0 {
+				preamble = append(preamble, line)
+			} else {
+				arg := args[len(args)-1]
+				arg.lines = append(arg.lines, line)
+			}
+		}
+	}
+
+	// Remove the arguments that don't exist, *args and **kwargs documented without asterisks are renamed
+	documented := make(map[string]bool)
+	for _, arg := range args {
+		documented[arg.name] = true
+	}
+	var kept []*docstringArgument
+	for _, arg := range args {
+		if !isParam[arg.name] {
+			renamed := false
+			for _, asterisks := range []string{"*", "**"} {
+				name := asterisks + arg.name
+				if arg.name != "" && isParam[name] && !documented[name] {
+					i := strings.Index(arg.lines[0], arg.name)
+					arg.lines[0] = arg.lines[0][:i] + asterisks + arg.lines[0][i:]
+					arg.name = name
+					documented[name] = true
+					renamed = true
+					break
+				}
+			}
+			if !renamed {
+				continue
+			}
+		}
+		kept = append(kept, arg)
+	}
+	args = kept
+
+	// Add the missing arguments after the closest preceding documented parameters
+	argPrefix := d.prefix + "  "
+	if len(args) > 0 {
+		argPrefix = strings.Repeat(" ", countLeadingSpaces(args[0].lines[0]))
+	}
+	for i, name := range params {
+		if documented[name] || !addMissing {
+			continue
+		}
+		arg := &docstringArgument{name, []string{argPrefix + name + ": " + argumentDescriptionPlaceholder}}
+		position := 0
+	preceding:
+		for j := i - 1; j >= 0; j-- {
+			for k, other := range args {
+				if other.name == params[j] {
+					position = k + 1
+					break preceding
+				}
+			}
+		}
+		args = append(args[:position], append([]*docstringArgument{arg}, args[position:]...)...)
+		documented[name] = true
+	}
+
+	section := []string{d.prefix + "Args:"}
+	section = append(section, preamble...)
+	for _, arg := range args {
+		section = append(section, arg.lines...)
+	}
+
+	hasPreamble := false
+	for _, line := range preamble {
+		hasPreamble = hasPreamble || !isBlank(line)
+	}
+
+	switch {
+	case header < 0 && len(args) > 0:
+		d.insertSection(section, "Returns:", "Deprecated:")
+	case header >= 0 && (len(args) > 0 || hasPreamble):
+		d.replace(header, end, section...)
+	case header >= 0:
+		// Nothing is left to document, remove the section together with a blank line
+		d.replace(header, end)
+		if header > 0 && isBlank(d.lines[header-1]) && header < len(d.lines) && isBlank(d.lines[header]) {
+			d.replace(header-1, header)
+		}
+	}
+	return d.stringExpr(doc)
+}
+
 func functionDocstringWarning(f *build.File) []*LinterFinding {
 	var findings []*LinterFinding
 
 	// Docstrings are required only for top-level functions
-	for _, stmt := range f.Stmt {
+	for i, stmt := range f.Stmt {
 		def, ok := stmt.(*build.DefStmt)
 		if !ok {
 			continue
@@ -235,7 +512,9 @@ func functionDocstringWarning(f *build.File) []*LinterFinding {
 
 		message := fmt.Sprintf(`The function %q has no docstring.
 A docstring is a string literal (not a comment) which should be the first statement of a function body (it may follow comment lines).`, def.Name)
-		finding := makeLinterFinding(def, message)
+		newDef := *def
+		newDef.Body = append([]build.Expr{newFunctionDocstring(def)}, def.Body...)
+		finding := makeLinterFinding(def, message, LinterReplacement{&f.Stmt[i], &newDef})
 		finding.End = def.ColonPos
 		findings = append(findings, finding)
 	}
@@ -282,6 +561,7 @@ func functionDocstringArgsWarning(f *build.File) []*LinterFinding {
 		}
 
 		info := parseFunctionDocstring((*doc).(*build.StringExpr))
+		firstFinding := len(findings) // the fix is attached to the first finding of the function
 
 		if info.argumentsPos.LineRune > 0 {
 			argumentsEnd := info.argumentsPos
@@ -293,7 +573,15 @@ func functionDocstringArgsWarning(f *build.File) []*LinterFinding {
 			findings = append(findings, finding)
 		}
 
-		if !isDocstringRequired(def) && len(info.args) == 0 {
+		addMissing := isDocstringRequired(def) || len(info.args) > 0
+		defer func() {
+			if len(findings) > firstFinding {
+				newDoc := fixDocstringArgs((*doc).(*build.StringExpr), def, addMissing)
+				findings[firstFinding].Replacement = []LinterReplacement{{doc, newDoc}}
+			}
+		}()
+
+		if !addMissing {
 			return
 		}
 
@@ -301,14 +589,14 @@ func functionDocstringArgsWarning(f *build.File) []*LinterFinding {
 
 		// Check whether all arguments are documented.
 		notDocumentedArguments := []string{}
-		paramNames := make(map[string]bool)
+		isParam := make(map[string]bool)
 		for _, param := range def.Params {
 			name, op := build.GetParamName(param)
 			if name == "" {
 				continue
 			}
 			name = op + name  // *args or **kwargs
-			paramNames[name] = true
+			isParam[name] = true
 			if _, ok := info.args[name]; !ok {
 				notDocumentedArguments = append(notDocumentedArguments, name)
 			}
@@ -350,13 +638,13 @@ one (preferably two) space more than "Args:", for example:
 
 		// Check whether all documented arguments actually exist in the function signature.
 		for name, pos := range info.args {
-			if paramNames[name] {
+			if isParam[name] {
 				continue
 			}
 			msg := fmt.Sprintf("Argument %q is documented but doesn't exist in the function signature.", name)
 			// *args and **kwargs should be documented with asterisks
 			for _, asterisks := range []string{"*", "**"} {
-				if paramNames[asterisks+name] {
+				if isParam[asterisks+name] {
 					msg += fmt.Sprintf(` Do you mean "%s%s"?`, asterisks, name)
 					break
 				}
@@ -392,7 +680,10 @@ func functionDocstringReturnWarning(f *build.File) []*LinterFinding {
 		// Check whether the return value is documented
 		if isDocstringRequired(def) && hasReturnValues(def) && !info.returns {
 			message := fmt.Sprintf("Return value of %q is not documented.", def.Name)
-			findings = append(findings, makeLinterFinding(*doc, message))
+			d := newDocstringLines((*doc).(*build.StringExpr))
+			d.insertSection([]string{d.prefix + "Returns:", d.prefix + "  " + returnDescriptionPlaceholder}, "Deprecated:")
+			newDoc := d.stringExpr((*doc).(*build.StringExpr))
+			findings = append(findings, makeLinterFinding(*doc, message, LinterReplacement{doc, newDoc}))
 		}
 		return
 	})
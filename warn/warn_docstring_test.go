@@ -96,7 +96,7 @@ def f(x):
 		[]string{},
 		scopeEverywhere)
 
-	checkFindings(t, "function-docstring", `
+	checkFindingsAndFix(t, "function-docstring", `
 def f(x):
    # long function
    x += 1
@@ -105,6 +105,44 @@ def f(x):
    x -= 4
    x %= 5
    return x
+`, `
+def f(x):
+    """TODO: Describe the function.
+
+    Args:
+      x: TODO: Describe the argument.
+
+    Returns:
+      TODO: Describe the return value.
+    """
+
+    # long function
+    x += 1
+    x *= 2
+    x /= 3
+    x -= 4
+    x %= 5
+    return x
+`,
+		[]string{":1: The function \"f\" has no docstring."},
+		scopeEverywhere)
+
+	checkFindingsAndFix(t, "function-docstring", `
+def f():
+    foo()
+    foo()
+    foo()
+    foo()
+    foo()
+`, `
+def f():
+    """TODO: Describe the function.
+    """
+    foo()
+    foo()
+    foo()
+    foo()
+    foo()
 `,
 		[]string{":1: The function \"f\" has no docstring."},
 		scopeEverywhere)
@@ -274,7 +312,7 @@ def f(x):
 		[]string{},
 		scopeEverywhere)
 
-	checkFindings(t, "function-docstring-args", `
+	checkFindingsAndFix(t, "function-docstring-args", `
 def f(x, y):
   """Short function with a docstring
 
@@ -282,6 +320,15 @@ def f(x, y):
     x: smth
   """
   return x + y
+`, `
+def f(x, y):
+  """Short function with a docstring
+
+  Args:
+    x: smth
+    y: TODO: Describe the argument.
+  """
+  return x + y
 `,
 		[]string{
 			`2: Argument "y" is not documented.`,
@@ -289,7 +336,7 @@ def f(x, y):
 		},
 		scopeEverywhere)
 
-	checkFindings(t, "function-docstring-args", `
+	checkFindingsAndFix(t, "function-docstring-args", `
 def f():
   def g(x, y):
     """Short function with a docstring
@@ -299,6 +346,17 @@ def f():
     """
     return x + y
   return g
+`, `
+def f():
+  def g(x, y):
+    """Short function with a docstring
+
+    Args:
+      x: smth
+      y: TODO: Describe the argument.
+    """
+    return x + y
+  return g
 `,
 		[]string{
 			`3: Argument "y" is not documented.`,
@@ -317,7 +375,7 @@ def f():
 		[]string{},
 		scopeEverywhere)
 
-	checkFindings(t, "function-docstring-args", `
+	checkFindingsAndFix(t, "function-docstring-args", `
 def _f(x, y):
   """Long private function
   
@@ -330,6 +388,19 @@ def _f(x, y):
   x -= 4
   x %= 5
   return x
+`, `
+def _f(x, y):
+  """Long private function
+  
+  Args:
+    x: something
+    y: TODO: Describe the argument.
+  """
+  x *= 2
+  x /= 3
+  x -= 4
+  x %= 5
+  return x
 `,
 		[]string{
 			`:2: Argument "y" is not documented.`,
@@ -337,7 +408,7 @@ def _f(x, y):
 		},
 		scopeEverywhere)
 
-	checkFindings(t, "function-docstring-args", `
+	checkFindingsAndFix(t, "function-docstring-args", `
 def f(x, y):
    """This is a function.
 
@@ -346,6 +417,23 @@ def f(x, y):
         y: something (this is in fact the description of x continued)
      z: something else
 
+   Returns:
+     None
+   """
+   pass
+   pass
+   pass
+   pass
+   pass
+`, `
+def f(x, y):
+   """This is a function.
+
+   Args:
+     x: something
+        y: something (this is in fact the description of x continued)
+     y: TODO: Describe the argument.
+
    Returns:
      None
    """
@@ -361,7 +449,7 @@ def f(x, y):
 			`7: Argument "z" is documented but doesn't exist in the function signature.`,
 		}, scopeEverywhere)
 
-	checkFindings(t, "function-docstring-args", `
+	checkFindingsAndFix(t, "function-docstring-args", `
 def my_function(x, y, z = None, *args, **kwargs):
    """This is a function.
    """
@@ -370,6 +458,22 @@ def my_function(x, y, z = None, *args, **kwargs):
    pass
    pass
    pass
+`, `
+def my_function(x, y, z = None, *args, **kwargs):
+   """This is a function.
+
+   Args:
+     x: TODO: Describe the argument.
+     y: TODO: Describe the argument.
+     z: TODO: Describe the argument.
+     *args: TODO: Describe the argument.
+     **kwargs: TODO: Describe the argument.
+   """
+   pass
+   pass
+   pass
+   pass
+   pass
 `,
 		[]string{
 			`2: Arguments "x", "y", "z", "*args", "**kwargs" are not documented.
@@ -423,7 +527,7 @@ def f(x, *, y, z = None):
 		[]string{},
 		scopeEverywhere)
 
-	checkFindings(t, "function-docstring-args", `
+	checkFindingsAndFix(t, "function-docstring-args", `
 def f(x, *, y, z = None):
    """This is a function.
 
@@ -435,6 +539,16 @@ def f(x, *, y, z = None):
     z: z
    """
    pass
+`, `
+def f(x, *, y, z = None):
+   """This is a function.
+
+   Args:
+    x: x
+    y: y
+    z: z
+   """
+   pass
 `,
 		[]string{
 			`6: Argument "*" is documented but doesn't exist in the function signature.`,
@@ -465,7 +579,7 @@ def f(x):
 		[]string{},
 		scopeEverywhere)
 
-	checkFindings(t, "function-docstring-args", `
+	checkFindingsAndFix(t, "function-docstring-args", `
 def f(foobar, *bar, **baz):
   """Some function
   
@@ -476,6 +590,16 @@ def f(foobar, *bar, **baz):
     baz: something
   """
   pass
+`, `
+def f(foobar, *bar, **baz):
+  """Some function
+  
+  Args:
+    foobar: something
+    *bar: something
+    **baz: something
+  """
+  pass
 `,
 		[]string{
 			`:2: Arguments "*bar", "**baz" are not documented.`,
@@ -485,7 +609,7 @@ def f(foobar, *bar, **baz):
 		},
 		scopeEverywhere)
 
-	checkFindings(t, "function-docstring-args", `
+	checkFindingsAndFix(t, "function-docstring-args", `
 def f(x: int, y: str, z: bool = False, *, *bar: List[int], **baz: Mapping[str, bool]):
   """Some function
   
@@ -494,12 +618,121 @@ def f(x: int, y: str, z: bool = False, *, *bar: List[int], **baz: Mapping[str, b
     t: something
   """
   pass
+`, `
+def f(x: int, y: str, z: bool = False, *, *bar: List[int], **baz: Mapping[str, bool]):
+  """Some function
+  
+  Args:
+    x: something
+    y: TODO: Describe the argument.
+    z: TODO: Describe the argument.
+    *bar: TODO: Describe the argument.
+    **baz: TODO: Describe the argument.
+  """
+  pass
 `,
 		[]string{
 			`:2: Arguments "y", "z", "*bar", "**baz" are not documented.`,
 			`:6: Argument "t" is documented but doesn't exist in the function signature.`,
 		},
 		scopeEverywhere)
+
+	checkFindingsAndFix(t, "function-docstring-args", `
+def f(x, y):
+    """This is a function.
+
+    Returns:
+      something
+
+    Deprecated:
+      use g instead
+    """
+    pass
+    pass
+    pass
+    pass
+    return x
+`, `
+def f(x, y):
+    """This is a function.
+
+    Args:
+      x: TODO: Describe the argument.
+      y: TODO: Describe the argument.
+
+    Returns:
+      something
+
+    Deprecated:
+      use g instead
+    """
+    pass
+    pass
+    pass
+    pass
+    return x
+`,
+		[]string{
+			`:2: Arguments "x", "y" are not documented.`,
+		},
+		scopeEverywhere)
+
+	checkFindingsAndFix(t, "function-docstring-args", `
+def f(y, z):
+    """This is a function.
+
+    Args:
+      z: something
+        else
+    """
+    pass
+    pass
+    pass
+    pass
+`, `
+def f(y, z):
+    """This is a function.
+
+    Args:
+      y: TODO: Describe the argument.
+      z: something
+        else
+    """
+    pass
+    pass
+    pass
+    pass
+`,
+		[]string{
+			`:2: Argument "y" is not documented.`,
+		},
+		scopeEverywhere)
+
+	checkFindingsAndFix(t, "function-docstring-args", `
+def f():
+    """This is a function.
+
+    Args:
+      x: something
+        that doesn't exist
+
+    Returns:
+      None
+    """
+    pass
+`, `
+def f():
+    """This is a function.
+
+    Returns:
+      None
+    """
+    pass
+`,
+		[]string{
+			`:5: Argument "x" is documented but doesn't exist in the function signature.`,
+		},
+		scopeEverywhere)
 }
 
 func TestFunctionDocstringReturn(t *testing.T) {
@@ -558,12 +791,28 @@ def f(x):
 		[]string{},
 		scopeEverywhere)
 
-	checkFindings(t, "function-docstring-return", `
+	checkFindingsAndFix(t, "function-docstring-return", `
+def f(x):
+   """This is a function.
+
+   Args:
+     x: something
+   """
+   pass
+   pass
+   pass
+   pass
+   pass
+   return x
+`, `
 def f(x):
    """This is a function.
 
    Args:
      x: something
+
+   Returns:
+     TODO: Describe the return value.
    """
    pass
    pass
@@ -594,12 +843,31 @@ def f():
 		[]string{},
 		scopeEverywhere)
 
-	checkFindings(t, "function-docstring-return", `
+	checkFindingsAndFix(t, "function-docstring-return", `
+def f(x):
+   """This is a function.
+
+   Args:
+     x: something
+   """
+   pass
+   pass
+   pass
+   pass
+   pass
+   if foo:
+     return
+   else:
+     return x
+`, `
 def f(x):
    """This is a function.
 
    Args:
      x: something
+
+   Returns:
+     TODO: Describe the return value.
    """
    pass
    pass
@@ -610,6 +878,66 @@ def f(x):
      return
    else:
      return x
+`,
+		[]string{`2: Return value of "f" is not documented.`},
+		scopeEverywhere)
+
+	checkFindingsAndFix(t, "function-docstring-return", `
+def f():
+    """This is a function."""
+    pass
+    pass
+    pass
+    pass
+    return x
+`, `
+def f():
+    """This is a function.
+
+    Returns:
+      TODO: Describe the return value.
+    """
+    pass
+    pass
+    pass
+    pass
+    return x
+`,
+		[]string{`2: Return value of "f" is not documented.`},
+		scopeEverywhere)
+
+	checkFindingsAndFix(t, "function-docstring-return", `
+def f(x):
+    """This is a function.
+
+    Args:
+      x: something
+    Deprecated:
+      use g instead
+    """
+    pass
+    pass
+    pass
+    pass
+    return x
+`, `
+def f(x):
+    """This is a function.
+
+    Args:
+      x: something
+
+    Returns:
+      TODO: Describe the return value.
+
+    Deprecated:
+      use g instead
+    """
+    pass
+    pass
+    pass
+    pass
+    return x
 `,
 		[]string{`2: Return value of "f" is not documented.`},
 		scopeEverywhere)
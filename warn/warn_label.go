@@ -0,0 +1,300 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Warnings about labels that can't be resolved
+
+package warn
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/labels"
+	"github.com/bazelbuild/buildtools/lang"
+	"github.com/bazelbuild/buildtools/tables"
+
+	buildpb "github.com/bazelbuild/buildtools/build_proto"
+)
+
+// unresolvedLabelSkippedAttrs are label attributes whose values don't necessarily refer to targets.
+var unresolvedLabelSkippedAttrs = map[string]bool{
+	"default_visibility": true,
+	"includes":           true,
+	"visibility":         true,
+}
+
+// nonTargetFunctions are functions that can be called in BUILD files but don't declare targets.
+var nonTargetFunctions = map[string]bool{
+	"licenses": true,
+	"package":  true,
+	"print":    true,
+	"fail":     true,
+}
+
+// packageTargets describes the targets declared in a BUILD file.
+type packageTargets struct {
+	names             map[string]bool        // targets declared literally
+	rules             map[string]*build.Rule // rules declared with literal names
+	prefixes          []string               // names of targets whose implicit outputs or generated targets may use them as prefixes
+	unknown           bool                   // whether the file declares targets that can't be determined statically
+	defaultVisibility build.Expr             // the `default_visibility` argument of `package()`, if any
+	path              string                 // the path of the BUILD file relative to the workspace root
+}
+
+// contains returns whether the target may be declared in the BUILD file. Implicit outputs and
+// targets generated by macros are assumed to be named after the targets declared in the file,
+// e.g. `foo_deploy.jar` or `libfoo.so` for `foo`, typos in the rest of such names aren't detected.
+func (pt *packageTargets) contains(target string) bool {
+	if pt.unknown || pt.names[target] {
+		return true
+	}
+	for _, prefix := range pt.prefixes {
+		for _, p := range []string{prefix, "lib" + prefix} {
+			if strings.HasPrefix(target, p) && len(target) > len(p) && strings.ContainsRune("_.-/", rune(target[len(p)])) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// outputValues returns the string literals of an output attribute value.
+func outputValues(expr build.Expr) []string {
+	var values []string
+	for _, str := range labelStrings(expr) {
+		values = append(values, str.Value)
+	}
+	return values
+}
+
+// analyzePackage collects the targets declared in a BUILD file. Targets generated by macros
+// are only recognized if their names start with the names of the macro calls.
+func analyzePackage(f *build.File, fileReader *FileReader) *packageTargets {
+	pt := &packageTargets{
+		names: make(map[string]bool),
+		rules: make(map[string]*build.Rule),
+		path:  path.Join(f.Pkg, f.Label),
+	}
+	customSchemas := RuleSchemas(f, fileReader)
+	for _, stmt := range f.Stmt {
+		call, ok := stmt.(*build.CallExpr)
+		if !ok {
+			switch stmt.(type) {
+			case *build.LoadStmt, *build.AssignExpr, *build.CommentBlock, *build.StringExpr:
+			default:
+				// Comprehensions, conditional statements, etc.
+				pt.unknown = true
+			}
+			continue
+		}
+		rule := f.Rule(call)
+		kind := rule.Kind()
+		if kind == "exports_files" {
+			if len(rule.Call.List) > 0 {
+				for _, name := range outputValues(rule.Call.List[0]) {
+					pt.names[name] = true
+				}
+			}
+			continue
+		}
+		if kind == "package" {
+			pt.defaultVisibility = rule.Attr("default_visibility")
+		}
+		if nonTargetFunctions[kind] {
+			continue
+		}
+		name := rule.ExplicitName()
+		if name == "" {
+			// The name is missing or is not a string literal
+			pt.unknown = true
+			continue
+		}
+		pt.names[name] = true
+		pt.rules[name] = rule
+		pt.prefixes = append(pt.prefixes, name)
+
+		for _, attr := range rule.AttrKeys() {
+			typ := lang.TypeOf[attr]
+			if schema, ok := customSchemas[kind][attr]; ok {
+				typ = schema.Type
+			}
+			if typ == buildpb.Attribute_OUTPUT || typ == buildpb.Attribute_OUTPUT_LIST {
+				for _, out := range outputValues(rule.Attr(attr)) {
+					pt.names[out] = true
+				}
+			}
+		}
+	}
+	return pt
+}
+
+// packageTargets returns the targets declared in the BUILD file of a package, or nil if
+// the BUILD file can't be read.
+func (fr *FileReader) packageTargets(pkg string) *packageTargets {
+	if fr.packages == nil {
+		fr.packages = make(map[string]*packageTargets)
+	}
+	if pt, ok := fr.packages[pkg]; ok {
+		return pt
+	}
+	var pt *packageTargets
+	for _, name := range []string{"BUILD.bazel", "BUILD"} {
+		if f := fr.GetFile(pkg, name); f != nil {
+			pt = analyzePackage(f, fr)
+			break
+		}
+	}
+	fr.packages[pkg] = pt
+	return pt
+}
+
+// labelStrings returns the string literals of an attribute value that are labels.
+// Patterns of globs and unknown function calls are skipped, for selects only the values
+// are considered.
+func labelStrings(expr build.Expr) []*build.StringExpr {
+	switch expr := expr.(type) {
+	case *build.StringExpr:
+		return []*build.StringExpr{expr}
+	case *build.ListExpr:
+		var result []*build.StringExpr
+		for _, item := range expr.List {
+			result = append(result, labelStrings(item)...)
+		}
+		return result
+	case *build.BinaryExpr:
+		if expr.Op != "+" {
+			return nil
+		}
+		return append(labelStrings(expr.X), labelStrings(expr.Y)...)
+	case *build.CallExpr:
+		if ident, ok := expr.X.(*build.Ident); !ok || ident.Name != "select" || len(expr.List) == 0 {
+			return nil
+		}
+		dict, ok := expr.List[0].(*build.DictExpr)
+		if !ok {
+			return nil
+		}
+		var result []*build.StringExpr
+		for _, kv := range dict.List {
+			result = append(result, labelStrings(kv.Value)...)
+		}
+		return result
+	}
+	return nil
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// closestName returns the name that is the most similar to the target, or an empty string
+// if no name is similar enough.
+func closestName(target string, names map[string]bool) string {
+	var sorted []string
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	best := ""
+	bestDistance := len(target)/3 + 1
+	for _, name := range sorted {
+		if d := editDistance(target, name); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// forEachLabel calls the function for each label in label-typed attributes of the rule that
+// refers to a target in the main repository (possibly by its apparent name, according to the
+// repository mapping of the fileReader which can be nil). Labels that contain make variables
+// or format strings are skipped.
+func forEachLabel(rule *build.Rule, pkg string, fileReader *FileReader, fct func(attr string, str *build.StringExpr, label labels.Label)) {
+	for _, attr := range rule.AttrKeys() {
+		if !tables.IsLabelArg[attr] || tables.LabelDenylist[rule.Kind()+"."+attr] || unresolvedLabelSkippedAttrs[attr] {
+			continue
+		}
+		for _, str := range labelStrings(rule.Attr(attr)) {
+			if str.Value == "" || strings.ContainsAny(str.Value, "$%{") {
+				continue
+			}
+			label := fileReader.resolveLabel(str.Value, pkg)
+			if label.Repository != "" || label.Target == "" {
+				continue
+			}
+			fct(attr, str, label)
+		}
+	}
+}
+
+func unresolvedLabelWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
+	if f.Type != build.TypeBuild || fileReader == nil {
+		return nil
+	}
+
+	var findings []*LinterFinding
+	targets := analyzePackage(f, fileReader)
+	for _, rule := range f.Rules("") {
+		forEachLabel(rule, f.Pkg, fileReader, func(attr string, str *build.StringExpr, label labels.Label) {
+			pt := targets
+			if label.Package != f.Pkg {
+				pt = fileReader.packageTargets(label.Package)
+			}
+			if pt == nil || pt.contains(label.Target) || fileReader.fileExists(path.Join(label.Package, label.Target)) {
+				return
+			}
+
+			message := fmt.Sprintf("The label %q in the %q attribute doesn't refer to an existing target or file.", str.Value, attr)
+			if suggestion := closestName(label.Target, pt.names); suggestion != "" {
+				suggested := labels.Label{Package: label.Package, Target: suggestion}.FormatRelative(f.Pkg)
+				message += fmt.Sprintf(" Did you mean %q?", suggested)
+			}
+			findings = append(findings, makeLinterFinding(str, message))
+		})
+	}
+	return findings
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warn
+
+import (
+	"os"
+	"testing"
+
+	"github.com/bazelbuild/buildtools/labels"
+)
+
+func TestUnresolvedLabelNoReader(t *testing.T) {
+	checkFindings(t, "unresolved-label", `
+cc_library(
+    name = "foo",
+    deps = [":bar"],
+)
+`, []string{}, scopeEverywhere)
+}
+
+func TestUnresolvedLabel(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"test/package/foo.cc":  "",
+		"test/package/sub/a.h": "",
+		"lib/BUILD": `
+cc_library(
+    name = "util",
+    srcs = ["util.cc"],
+)
+
+genrule(
+    name = "gen",
+    outs = ["gen.h"],
+)
+
+exports_files(["data.txt"])
+
+my_macro(name = "macro")
+`,
+		"generated/BUILD.bazel": `
+[cc_library(name = n) for n in ["a", "b"]]
+`,
+	})()
+
+	checkFindings(t, "unresolved-label", `
+cc_library(
+    name = "foo_lib",
+    srcs = ["foo.cc", "fooo.cc", "sub/a.h"] + glob(["*.c"]),
+    deps = [
+        ":foo-lib",
+        ":foo_test",
+        "//lib:util",
+        "//lib:utils",
+        "//lib:gen.h",
+        "//lib:data.txt",
+        "//lib:macro_generated",
+        "//lib:unknown",
+        "//generated:c",
+        "//nonexistent:target",
+        "@repo//:target",
+        "$(location :foo)",
+    ] + select({
+        "//conditions:default": [":bar"],
+    }),
+    visibility = ["//visibility:private"],
+)
+
+cc_test(
+    name = "foo_test",
+    srcs = ["test.cc", "foo_test.cc"],
+    deps = [":foo_lib"],
+)
+`,
+		[]string{
+			`:3: The label "fooo.cc" in the "srcs" attribute doesn't refer to an existing target or file.`,
+			`:5: The label ":foo-lib" in the "deps" attribute doesn't refer to an existing target or file. Did you mean ":foo_lib"?`,
+			`:8: The label "//lib:utils" in the "deps" attribute doesn't refer to an existing target or file. Did you mean "//lib:util"?`,
+			`:12: The label "//lib:unknown" in the "deps" attribute doesn't refer to an existing target or file.`,
+			`:18: The label ":bar" in the "deps" attribute doesn't refer to an existing target or file.`,
+			`:25: The label "test.cc" in the "srcs" attribute doesn't refer to an existing target or file.`,
+		},
+		scopeBuild)
+}
+
+func TestUnresolvedLabelStatFile(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"test/package/BUILD": "",
+	})()
+	testFileReader.SetStatFile(func(filename string) (os.FileInfo, error) {
+		switch filename {
+		case "test/package/data":
+			return nil, nil
+		case "test/package/secret.txt":
+			return nil, &os.PathError{Op: "stat", Path: filename, Err: os.ErrPermission}
+		}
+		return nil, &os.PathError{Op: "stat", Path: filename, Err: os.ErrNotExist}
+	})
+
+	checkFindings(t, "unresolved-label", `
+filegroup(
+    name = "files",
+    srcs = ["data", "secret.txt", "missing.txt"],
+)
+`,
+		[]string{
+			`:3: The label "secret.txt" in the "srcs" attribute doesn't refer to an existing target or file.`,
+			`:3: The label "missing.txt" in the "srcs" attribute doesn't refer to an existing target or file.`,
+		},
+		scopeBuild)
+
+	for _, filename := range fileReaderRequests {
+		if filename != "test/package/BUILD" && filename != "test/package/BUILD.bazel" {
+			t.Errorf("unexpected file read: %q", filename)
+		}
+	}
+}
+
+func TestUnresolvedLabelRepoMapping(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"lib/BUILD": `cc_library(name = "util")`,
+	})()
+	testFileReader.SetRepoMapping(labels.NewRepoMapping(map[string]string{
+		"my_module": "",
+		"rules_cc":  "rules_cc+",
+	}))
+
+	checkFindings(t, "unresolved-label", `
+cc_library(
+    name = "foo",
+    deps = [
+        "@my_module//lib:util",
+        "@my_module//lib:utils",
+        "@@//lib:missing",
+        "@rules_cc//cc:missing",
+        "@@rules_cc+//cc:missing",
+    ],
+)
+`,
+		[]string{
+			`:5: The label "@my_module//lib:utils" in the "deps" attribute doesn't refer to an existing target or file. Did you mean "//lib:util"?`,
+			`:6: The label "@@//lib:missing" in the "deps" attribute doesn't refer to an existing target or file.`,
+		},
+		scopeBuild)
+}
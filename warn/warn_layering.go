@@ -0,0 +1,120 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Layering rules for dependencies between packages read from a config file
+
+package warn
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/labels"
+)
+
+// LayeringConfig is the content of a JSON file with rules checked by the "layering" warning.
+type LayeringConfig struct {
+	Rules []LayeringRule `json:"rules"`
+}
+
+// LayeringRule restricts the dependencies of packages on other packages. All package
+// patterns have the same format as in Policy (e.g. "//app/...").
+type LayeringRule struct {
+	Name    string `json:"name"`    // name of the rule, used in warning messages
+	Message string `json:"message"` // optional explanation, used in warning messages
+
+	From  []string `json:"from"`  // patterns of the packages the rule applies to
+	Allow []string `json:"allow"` // if not empty, dependencies should be on packages matching one of these patterns
+	Deny  []string `json:"deny"`  // dependencies shouldn't be on packages matching any of these patterns
+}
+
+// layeringRules are the currently used layering rules.
+var layeringRules []LayeringRule
+
+// ParseLayeringFile reads layering rules from a JSON file and replaces the currently used rules.
+func ParseLayeringFile(file string) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var config LayeringConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+	return SetLayeringRules(config.Rules)
+}
+
+// SetLayeringRules replaces the currently used layering rules.
+func SetLayeringRules(rules []LayeringRule) error {
+	for i, r := range rules {
+		if len(r.From) == 0 {
+			return fmt.Errorf("layering rule #%d (%q): \"from\" shouldn't be empty", i+1, r.Name)
+		}
+		if len(r.Allow) == 0 && len(r.Deny) == 0 {
+			return fmt.Errorf("layering rule #%d (%q): either \"allow\" or \"deny\" should be set", i+1, r.Name)
+		}
+	}
+	layeringRules = rules
+	return nil
+}
+
+// violated checks whether a dependency of a package on another package violates the rule.
+func (r *LayeringRule) violated(from, to string) bool {
+	matches := func(pkg string) func(string) bool {
+		return func(pattern string) bool { return matchPackagePattern(pattern, pkg) }
+	}
+	if !matchAny(r.From, matches(from)) {
+		return false
+	}
+	return matchAny(r.Deny, matches(to)) || (len(r.Allow) > 0 && !matchAny(r.Allow, matches(to)))
+}
+
+// message formats a warning message for a violation of the rule.
+func (r *LayeringRule) message(from string, label string) string {
+	msg := fmt.Sprintf("the package %q shouldn't depend on %q", "//"+from, label)
+	switch {
+	case r.Message != "":
+		return r.Message + ": " + msg + "."
+	case r.Name != "":
+		return fmt.Sprintf("Layering rule %q is violated: %s.", r.Name, msg)
+	}
+	return fmt.Sprintf("Layering rule is violated: %s.", msg)
+}
+
+func layeringWarning(f *build.File) []*LinterFinding {
+	if f.Type != build.TypeBuild || len(layeringRules) == 0 {
+		return nil
+	}
+
+	var findings []*LinterFinding
+	for _, rule := range f.Rules("") {
+		forEachLabel(rule, f.Pkg, nil, func(attr string, str *build.StringExpr, label labels.Label) {
+			if label.Package == f.Pkg {
+				return
+			}
+			for i := range layeringRules {
+				if layeringRules[i].violated(f.Pkg, label.Package) {
+					findings = append(findings, makeLinterFinding(str, layeringRules[i].message(f.Pkg, label.Format())))
+					// Report each dependency only once
+					return
+				}
+			}
+		})
+	}
+	return findings
+}
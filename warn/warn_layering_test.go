@@ -0,0 +1,101 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warn
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func setUpLayeringRules(t *testing.T, config string) (cleanup func()) {
+	dir, err := ioutil.TempDir(os.Getenv("TEST_TMPDIR"), "layering")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "layering.json")
+	if err := ioutil.WriteFile(file, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ParseLayeringFile(file); err != nil {
+		t.Fatal(err)
+	}
+	return func() {
+		layeringRules = nil
+		os.RemoveAll(dir)
+	}
+}
+
+func TestLayering(t *testing.T) {
+	defer setUpLayeringRules(t, `{
+  "rules": [
+    {
+      "name": "lib-not-app",
+      "from": ["//test/..."],
+      "deny": ["//app/..."]
+    },
+    {
+      "message": "Tests may only use test utilities",
+      "from": ["//test/package"],
+      "allow": ["//lib/...", "//testing/*"]
+    }
+  ]
+}`)()
+
+	checkFindings(t, "layering", `
+java_library(
+    name = "a",
+    srcs = ["A.java"],
+    deps = [
+        ":b",
+        "//app/main:util",
+        "//lib/base",
+        "//testing/util",
+        "//testing/util/internal",
+        "@other//app:lib",
+    ],
+    runtime_deps = select({
+        "//conditions:default": ["//other"],
+    }),
+    visibility = ["//app:__pkg__"],
+)
+
+java_library(
+    name = "b",
+    # buildifier: disable=layering
+    deps = ["//app"],
+)
+`,
+		[]string{
+			`:6: Layering rule "lib-not-app" is violated: the package "//test/package" shouldn't depend on "//app/main:util".`,
+			`:9: Tests may only use test utilities: the package "//test/package" shouldn't depend on "//testing/util/internal".`,
+			`:13: Tests may only use test utilities: the package "//test/package" shouldn't depend on "//other".`,
+		},
+		scopeBuild)
+}
+
+func TestLayeringInvalidRules(t *testing.T) {
+	defer func() { layeringRules = nil }()
+
+	if err := SetLayeringRules([]LayeringRule{{Name: "no-from", Deny: []string{"//app/..."}}}); err == nil {
+		t.Error("SetLayeringRules: expected an error for a rule without \"from\"")
+	}
+	if err := SetLayeringRules([]LayeringRule{{Name: "no-deps", From: []string{"//lib/..."}}}); err == nil {
+		t.Error("SetLayeringRules: expected an error for a rule without \"allow\" and \"deny\"")
+	}
+}
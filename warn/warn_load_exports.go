@@ -0,0 +1,326 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Warnings about loaded symbols that are not exported by the loaded files
+
+package warn
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/bzlenv"
+	"github.com/bazelbuild/buildtools/edit"
+	"github.com/bazelbuild/buildtools/labels"
+)
+
+// exportStatus describes whether a symbol is exported by a .bzl file.
+type exportStatus int
+
+const (
+	exportUnknown    exportStatus = iota // the file can't be read or analyzed
+	exportDefined                        // the symbol is defined in the file
+	exportPrivate                        // the symbol is private (starts with "_")
+	exportLoadedOnly                     // the symbol is loaded by the file but not defined in it
+	exportUndefined                      // the symbol is not defined in the file
+)
+
+// symbolOrigin is a symbol defined at the top level of a .bzl file.
+type symbolOrigin struct {
+	label labels.Label // the file that defines the symbol
+	name  string       // the name of the symbol in the file
+}
+
+// publicSymbols returns the names of the public symbols defined at the top level of a file.
+func publicSymbols(f *build.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, stmt := range f.Stmt {
+		var idents []*build.Ident
+		switch stmt := stmt.(type) {
+		case *build.DefStmt:
+			idents = append(idents, &build.Ident{Name: stmt.Name})
+		case *build.AssignExpr:
+			idents = bzlenv.CollectLValues(stmt.LHS)
+		}
+		for _, ident := range idents {
+			if !strings.HasPrefix(ident.Name, "_") {
+				names[ident.Name] = true
+			}
+		}
+	}
+	return names
+}
+
+// resolveExport checks whether a symbol is exported by a .bzl file of the main repository
+// and returns the file that defines it, following re-exports of loaded symbols such as
+//
+//	load(":impl.bzl", _foo = "foo")
+//	foo = _foo
+//
+// For symbols that are only loaded by the file the returned origin is where they're loaded from.
+func (fr *FileReader) resolveExport(label labels.Label, name string, visited map[symbolOrigin]bool) (symbolOrigin, exportStatus) {
+	origin := symbolOrigin{label, name}
+	if strings.HasPrefix(name, "_") {
+		return origin, exportPrivate
+	}
+	if visited[origin] {
+		return origin, exportUnknown
+	}
+	visited[origin] = true
+
+	f := fr.GetFile(label.Package, label.Target)
+	if f == nil {
+		return origin, exportUnknown
+	}
+
+	// Symbols loaded by the file by their local names
+	loaded := make(map[string]symbolOrigin)
+	for _, stmt := range f.Stmt {
+		load, ok := stmt.(*build.LoadStmt)
+		if !ok {
+			continue
+		}
+		module := labels.ParseRelative(load.Module.Value, f.Pkg)
+		for i, to := range load.To {
+			loaded[to.Name] = symbolOrigin{module, load.From[i].Name}
+		}
+	}
+
+	// follow returns the origin of a symbol loaded by the file, if it can be determined
+	follow := func(from symbolOrigin) (symbolOrigin, bool) {
+		if from.label.Repository != "" || from.label.Target == "" {
+			return from, false
+		}
+		if next, status := fr.resolveExport(from.label, from.name, visited); status == exportDefined {
+			return next, true
+		}
+		return from, false
+	}
+
+	for _, stmt := range f.Stmt {
+		switch stmt := stmt.(type) {
+		case *build.DefStmt:
+			if stmt.Name == name {
+				return origin, exportDefined
+			}
+		case *build.AssignExpr:
+			for _, ident := range bzlenv.CollectLValues(stmt.LHS) {
+				if ident.Name != name {
+					continue
+				}
+				if rhs, ok := stmt.RHS.(*build.Ident); ok && stmt.Op == "=" && ident == stmt.LHS {
+					if from, ok := loaded[rhs.Name]; ok {
+						if next, ok := follow(from); ok {
+							return next, exportDefined
+						}
+					}
+				}
+				return origin, exportDefined
+			}
+		case *build.IfStmt, *build.ForStmt:
+			// Not allowed at the top level of .bzl files, symbols can't be determined statically
+			return origin, exportUnknown
+		}
+	}
+	if from, ok := loaded[name]; ok {
+		next, _ := follow(from)
+		return next, exportLoadedOnly
+	}
+	return origin, exportUndefined
+}
+
+// loadMove is a symbol that should be loaded from a different file.
+type loadMove struct {
+	label    labels.Label
+	from, to string
+}
+
+// loadRewriter collects modifications of the load statements of a file.
+type loadRewriter struct {
+	f        *build.File
+	newLoads map[*build.LoadStmt]*build.LoadStmt // modified copies of the original load statements
+	moves    []loadMove
+	after    map[labels.Label]*build.LoadStmt // the statements new load statements are inserted after
+}
+
+// modified returns a modified copy of a load statement.
+func (lr *loadRewriter) modified(load *build.LoadStmt) *build.LoadStmt {
+	if newLoad, ok := lr.newLoads[load]; ok {
+		return newLoad
+	}
+	newLoad := *load
+	newLoad.From = append([]*build.Ident{}, load.From...)
+	newLoad.To = append([]*build.Ident{}, load.To...)
+	lr.newLoads[load] = &newLoad
+	return &newLoad
+}
+
+// move removes a loaded symbol from its load statement and loads it from a different file
+// with the given local name.
+func (lr *loadRewriter) move(load *build.LoadStmt, to *build.Ident, origin symbolOrigin, name string) {
+	newLoad := lr.modified(load)
+	for i, ident := range newLoad.To {
+		if ident == to {
+			newLoad.From = append(newLoad.From[:i], newLoad.From[i+1:]...)
+			newLoad.To = append(newLoad.To[:i], newLoad.To[i+1:]...)
+			break
+		}
+	}
+	if _, ok := lr.after[origin.label]; !ok {
+		lr.after[origin.label] = load
+	}
+	lr.moves = append(lr.moves, loadMove{origin.label, origin.name, name})
+}
+
+// replacements returns the replacements of the modified load statements. New load statements
+// are inserted right after the first statements the symbols are moved from: these statements
+// are replaced with statement lists, so that the file is only modified if the fix is applied.
+func (lr *loadRewriter) replacements() []LinterReplacement {
+	existing := make(map[labels.Label]*build.LoadStmt)
+	for _, stmt := range lr.f.Stmt {
+		if load, ok := stmt.(*build.LoadStmt); ok {
+			label := labels.ParseRelative(load.Module.Value, lr.f.Pkg)
+			if _, ok := existing[label]; !ok {
+				existing[label] = load
+			}
+		}
+	}
+
+	inserted := make(map[*build.LoadStmt][]*build.LoadStmt) // new load statements by the statements they follow
+	created := make(map[labels.Label]*build.LoadStmt)
+	for _, m := range lr.moves {
+		var target *build.LoadStmt
+		if load, ok := existing[m.label]; ok {
+			target = lr.modified(load)
+		} else if load, ok := created[m.label]; ok {
+			target = load
+		} else {
+			target = edit.NewLoad(m.label.FormatRelative(lr.f.Pkg), nil, nil)
+			after := lr.after[m.label]
+			if after != nil {
+				// Findings of other warnings can refer to the new statement
+				target.Load, target.Rparen = after.Load, after.Rparen
+			}
+			created[m.label] = target
+			// Each new statement is inserted right after the original one, before the statements
+			// inserted earlier
+			inserted[after] = append([]*build.LoadStmt{target}, inserted[after]...)
+		}
+		edit.AppendToLoad(target, []string{m.from}, []string{m.to})
+	}
+
+	var replacements []LinterReplacement
+	for i, stmt := range lr.f.Stmt {
+		var newStmt build.Expr = stmt
+		modified := false
+		if load, ok := stmt.(*build.LoadStmt); ok {
+			if newLoad, ok := lr.newLoads[load]; ok {
+				build.SortLoadArgs(newLoad)
+				newStmt = newLoad
+				if len(newLoad.To) == 0 {
+					// If there are no loaded symbols left remove the entire load statement
+					newStmt = nil
+				}
+				modified = true
+			}
+			if newLoads := inserted[load]; len(newLoads) > 0 {
+				list := &statementList{}
+				if newStmt != nil {
+					list.List = append(list.List, newStmt)
+				}
+				for _, newLoad := range newLoads {
+					build.SortLoadArgs(newLoad)
+					list.List = append(list.List, newLoad)
+				}
+				newStmt = list
+				modified = true
+			}
+		}
+		if modified {
+			replacements = append(replacements, LinterReplacement{&lr.f.Stmt[i], newStmt})
+		}
+	}
+	return replacements
+}
+
+func loadExportsWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
+	if fileReader == nil {
+		return nil
+	}
+
+	var findings []*LinterFinding
+	var fixable *LinterFinding // the finding the combined fix is attached to
+	lr := &loadRewriter{
+		f:        f,
+		newLoads: make(map[*build.LoadStmt]*build.LoadStmt),
+		after:    make(map[labels.Label]*build.LoadStmt),
+	}
+	for _, stmt := range f.Stmt {
+		load, ok := stmt.(*build.LoadStmt)
+		if !ok {
+			continue
+		}
+		module := labels.ParseRelative(load.Module.Value, f.Pkg)
+		if module.Repository != "" || module.Target == "" {
+			continue
+		}
+		for i, from := range load.From {
+			to := load.To[i]
+			origin, status := fileReader.resolveExport(module, from.Name, make(map[symbolOrigin]bool))
+			var message string
+			switch status {
+			case exportPrivate:
+				message = fmt.Sprintf("The symbol %q can't be loaded from %q because it's private.", from.Name, module.Format())
+			case exportUndefined:
+				message = fmt.Sprintf("The symbol %q is not defined in %q.", from.Name, module.Format())
+				if loadedFile := fileReader.GetFile(module.Package, module.Target); loadedFile != nil {
+					if suggestion := closestName(from.Name, publicSymbols(loadedFile)); suggestion != "" {
+						message += fmt.Sprintf(" Did you mean %q?", suggestion)
+					}
+				}
+			case exportLoadedOnly:
+				message = fmt.Sprintf("The symbol %q is not exported by %q, it's only loaded there. Please load it from %q.",
+					from.Name, module.Format(), origin.label.Format())
+			case exportDefined:
+				if origin.label == module {
+					continue
+				}
+				message = fmt.Sprintf("The symbol %q loaded from %q is defined in %q", from.Name, module.Format(), origin.label.Format())
+				if origin.name != from.Name {
+					message += fmt.Sprintf(" as %q", origin.name)
+				}
+				message += ". Please load it from there."
+			default:
+				continue
+			}
+
+			finding := makeLinterFinding(from, message)
+			findings = append(findings, finding)
+			if status == exportDefined || status == exportLoadedOnly && origin.label != module {
+				lr.move(load, to, origin, to.Name)
+				if fixable == nil {
+					fixable = finding
+				}
+			}
+		}
+	}
+	if fixable != nil {
+		// Individual replacements can't be combined together, attach all of them to one finding
+		fixable.Replacement = lr.replacements()
+	}
+	return findings
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warn
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bazelbuild/buildtools/build"
+)
+
+func TestLoadExportsUndefinedAndPrivate(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"test/package/foo.bzl": `
+load("//lib:util.bzl", "helper")
+
+def foo():
+    pass
+
+def _private():
+    pass
+
+bar, (baz, qux) = 1, (2, 3)
+`,
+		"lib/util.bzl": `
+def helper():
+    pass
+`,
+	})()
+
+	checkFindings(t, "load-exports", `
+load(":foo.bzl", "foo", "bar", "qux", "fooo", "_private", "unknown")
+load(":nonexistent.bzl", "foo")
+load("@other//test/package:foo.bzl", "unknown")
+`,
+		[]string{
+			`:1: The symbol "fooo" is not defined in "//test/package:foo.bzl". Did you mean "foo"?`,
+			`:1: The symbol "_private" can't be loaded from "//test/package:foo.bzl" because it's private.`,
+			`:1: The symbol "unknown" is not defined in "//test/package:foo.bzl".`,
+		},
+		scopeEverywhere)
+}
+
+func TestLoadExportsReexports(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"test/package/foo.bzl": `
+load("//lib:impl.bzl", _foo = "foo", _bar = "bar_impl")
+load("//lib:util.bzl", "helper")
+load("@other//:ext.bzl", _ext = "ext")
+
+foo = _foo
+bar = _bar
+ext = _ext
+`,
+		"lib/impl.bzl": `
+load(":impl2.bzl", _foo = "foo")
+
+foo = _foo
+
+def bar_impl():
+    pass
+`,
+		"lib/impl2.bzl": `
+def foo():
+    pass
+`,
+		"lib/util.bzl": `
+def helper():
+    pass
+`,
+	})()
+
+	checkFindingsAndFix(t, "load-exports", `
+load(":foo.bzl", "bar", "ext", my_foo = "foo")
+load("//lib:util.bzl", "helper")
+load(":foo.bzl", "helper")
+
+my_foo()
+bar()
+ext()
+helper()
+`, `
+load(":foo.bzl", "ext")
+load("//lib:impl2.bzl", my_foo = "foo")
+load("//lib:impl.bzl", bar = "bar_impl")
+load("//lib:util.bzl", "helper")
+
+my_foo()
+bar()
+ext()
+helper()
+`,
+		[]string{
+			`:1: The symbol "bar" loaded from "//test/package:foo.bzl" is defined in "//lib:impl.bzl" as "bar_impl". Please load it from there.`,
+			`:1: The symbol "foo" loaded from "//test/package:foo.bzl" is defined in "//lib:impl2.bzl". Please load it from there.`,
+			`:3: The symbol "helper" is not exported by "//test/package:foo.bzl", it's only loaded there. Please load it from "//lib:util.bzl".`,
+		},
+		scopeEverywhere)
+}
+
+func TestLoadExportsFixWithOtherWarnings(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"test/package/foo.bzl": `
+load("//lib:impl.bzl", _foo = "foo")
+
+foo = _foo
+`,
+		"lib/impl.bzl": `
+def foo():
+    pass
+`,
+	})()
+
+	// The emptied load statement is removed before the other warning runs
+	fixed, findings := fixWarnings([]string{"load-exports", "module-docstring"}, `
+load(":foo.bzl", "foo")
+
+def f():
+    foo()
+`)
+	want := `load("//lib:impl.bzl", "foo")
+
+def f():
+    foo()
+`
+	if fixed != want {
+		t.Errorf("fixWarnings() = %q, want %q", fixed, want)
+	}
+	if got := strings.Join(findings, ", "); got != "module-docstring:1" {
+		t.Errorf("fixWarnings() findings = %q, want %q", got, "module-docstring:1")
+	}
+}
+
+func TestLoadExportsDoesntModifyFile(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"test/package/foo.bzl": `
+load("//lib:impl.bzl", _foo = "foo")
+
+foo = _foo
+`,
+		"lib/impl.bzl": `
+def foo():
+    pass
+`,
+	})()
+
+	input := `load(":foo.bzl", "foo")
+
+foo()
+`
+	for _, mode := range []LintMode{ModeWarn, ModeSuggest} {
+		f, err := build.ParseBzl("test/package/test.bzl", []byte(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Pkg = "test/package"
+		stmts := append([]build.Expr{}, f.Stmt...)
+
+		findings := FileWarnings(f, []string{"load-exports"}, nil, mode, testFileReader)
+		if len(findings) != 1 {
+			t.Errorf("mode %d: got %d findings, want 1", mode, len(findings))
+		}
+		if !reflect.DeepEqual(f.Stmt, stmts) {
+			t.Errorf("mode %d: the statements of the file were modified: %v", mode, f.Stmt)
+		}
+	}
+}
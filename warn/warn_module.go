@@ -0,0 +1,473 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Warnings for MODULE.bazel files
+
+package warn
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+)
+
+// moduleFunctionsWithoutDevDependency are the MODULE.bazel functions that don't accept
+// the `dev_dependency` parameter.
+var moduleFunctionsWithoutDevDependency = map[string]bool{
+	"archive_override":          true,
+	"git_override":              true,
+	"include":                   true,
+	"inject_repo":               true,
+	"local_path_override":       true,
+	"module":                    true,
+	"multiple_version_override": true,
+	"override_repo":             true,
+	"single_version_override":   true,
+	"use_repo":                  true,
+	"use_repo_rule":             true,
+}
+
+// moduleCall is a top-level function call in a MODULE.bazel file.
+type moduleCall struct {
+	call *build.CallExpr
+	expr *build.Expr // the statement or the right-hand side of the assignment containing the call
+}
+
+// moduleCalls returns all top-level function calls of a MODULE.bazel file, including the ones
+// which results are assigned to variables (e.g. `ext = use_extension(...)`).
+func moduleCalls(f *build.File) []moduleCall {
+	var calls []moduleCall
+	for i := range f.Stmt {
+		expr := &f.Stmt[i]
+		if assign, ok := (*expr).(*build.AssignExpr); ok {
+			expr = &assign.RHS
+		}
+		if call, ok := (*expr).(*build.CallExpr); ok {
+			calls = append(calls, moduleCall{call, expr})
+		}
+	}
+	return calls
+}
+
+// callWithoutArgs returns a copy of a function call without the arguments with the given indices.
+// The call is kept compact if it was written on a single line and has no keyword arguments left.
+func callWithoutArgs(call *build.CallExpr, indices map[int]bool) *build.CallExpr {
+	newCall := *call
+	newCall.List = []build.Expr{}
+	compact := true
+	for i, arg := range call.List {
+		if indices[i] {
+			continue
+		}
+		if _, ok := arg.(*build.AssignExpr); ok {
+			compact = false
+		}
+		newCall.List = append(newCall.List, arg)
+	}
+	start, end := call.Span()
+	newCall.ForceCompact = call.ForceCompact || (compact && start.Line == end.Line && len(newCall.List) > 1)
+	return &newCall
+}
+
+// isBazelDep checks whether a statement is a `bazel_dep` call and returns it.
+func isBazelDep(stmt build.Expr) (*build.Rule, bool) {
+	call, ok := stmt.(*build.CallExpr)
+	if !ok {
+		return nil, false
+	}
+	rule := build.NewRule(call)
+	return rule, rule.Kind() == "bazel_dep"
+}
+
+// bazelDepKey returns a string representation of the arguments of a `bazel_dep` call
+// that doesn't depend on their order or formatting.
+func bazelDepKey(rule *build.Rule) string {
+	var args []string
+	for _, attr := range rule.AttrKeys() {
+		args = append(args, attr+"="+build.FormatString(rule.Attr(attr)))
+	}
+	sort.Strings(args)
+	return strings.Join(args, ",")
+}
+
+func moduleDuplicatedDepWarning(f *build.File) []*LinterFinding {
+	if f.Type != build.TypeModule {
+		return nil
+	}
+
+	type dep struct {
+		rule *build.Rule
+		line int
+	}
+	seen := make(map[string]dep)
+	var findings []*LinterFinding
+	for i, stmt := range f.Stmt {
+		rule, ok := isBazelDep(stmt)
+		if !ok {
+			continue
+		}
+		name := rule.AttrString("name")
+		if name == "" {
+			continue
+		}
+		previous, ok := seen[name]
+		if !ok {
+			start, _ := rule.Call.Span()
+			seen[name] = dep{rule, start.Line}
+			continue
+		}
+		message := fmt.Sprintf(`A bazel_dep on "%s" is already declared on line %d.`, name, previous.line)
+		if bazelDepKey(previous.rule) == bazelDepKey(rule) {
+			// An exact duplicate, can be safely removed
+			findings = append(findings, makeLinterFinding(rule.Call, message, LinterReplacement{&f.Stmt[i], nil}))
+		} else {
+			findings = append(findings, makeLinterFinding(rule.Call, message))
+		}
+	}
+	return findings
+}
+
+func moduleDevDependencyWarning(f *build.File) []*LinterFinding {
+	if f.Type != build.TypeModule {
+		return nil
+	}
+
+	var findings []*LinterFinding
+	for _, c := range moduleCalls(f) {
+		ident, ok := c.call.X.(*build.Ident)
+		if !ok {
+			continue
+		}
+		for i, arg := range c.call.List {
+			assign, ok := arg.(*build.AssignExpr)
+			if !ok {
+				continue
+			}
+			if lhs, ok := assign.LHS.(*build.Ident); !ok || lhs.Name != "dev_dependency" {
+				continue
+			}
+			replacement := LinterReplacement{c.expr, callWithoutArgs(c.call, map[int]bool{i: true})}
+			if moduleFunctionsWithoutDevDependency[ident.Name] {
+				findings = append(findings, makeLinterFinding(assign,
+					fmt.Sprintf(`The function "%s" doesn't accept the "dev_dependency" parameter.`, ident.Name),
+					replacement))
+				continue
+			}
+			if value, ok := assign.RHS.(*build.Ident); ok && value.Name == "False" {
+				findings = append(findings, makeLinterFinding(assign,
+					`"dev_dependency = False" is the default value and can be omitted.`,
+					replacement))
+			}
+		}
+	}
+	return findings
+}
+
+// moduleUnsortedDepWarning only sorts consequent chunks of bazel_dep statements,
+// similar to outOfOrderLoadWarning.
+func moduleUnsortedDepWarning(f *build.File) []*LinterFinding {
+	if f.Type != build.TypeModule {
+		return nil
+	}
+
+	type dep struct {
+		index int
+		name  string
+	}
+
+	// Consequent chunks of bazel_dep statements (i.e. without statements of other types between them)
+	var chunks [][]dep
+	lastIndex := -2
+	for i, stmt := range f.Stmt {
+		rule, ok := isBazelDep(stmt)
+		if !ok {
+			continue
+		}
+		if i-lastIndex > 1 {
+			chunks = append(chunks, []dep{})
+		}
+		chunks[len(chunks)-1] = append(chunks[len(chunks)-1], dep{i, rule.AttrString("name")})
+		lastIndex = i
+	}
+
+	var findings []*LinterFinding
+	for _, chunk := range chunks {
+		sortedChunk := append([]dep{}, chunk...)
+		sort.SliceStable(sortedChunk, func(i, j int) bool {
+			return sortedChunk[i].name < sortedChunk[j].name
+		})
+
+		var replacements []LinterReplacement
+		for i, d := range chunk {
+			replacements = append(replacements, LinterReplacement{&f.Stmt[d.index], f.Stmt[sortedChunk[i].index]})
+		}
+
+		for i, d := range chunk {
+			if i == 0 || d.name >= chunk[i-1].name {
+				// Correct position
+				continue
+			}
+			findings = append(findings, makeLinterFinding(f.Stmt[d.index],
+				fmt.Sprintf(`The bazel_dep on "%s" is out of its lexicographical order.`, d.name),
+				replacements...))
+		}
+	}
+	return findings
+}
+
+func moduleOnTopWarning(f *build.File) []*LinterFinding {
+	if f.Type != build.TypeModule {
+		return nil
+	}
+
+	firstStmtIndex := -1 // index of the first statement which is not a comment or a docstring
+	for i, stmt := range f.Stmt {
+		_, isString := stmt.(*build.StringExpr) // typically a docstring
+		_, isComment := stmt.(*build.CommentBlock)
+		if isString || isComment || stmt == nil {
+			continue
+		}
+		if firstStmtIndex == -1 {
+			firstStmtIndex = i
+		}
+		call, ok := stmt.(*build.CallExpr)
+		if !ok || build.NewRule(call).Kind() != "module" {
+			continue
+		}
+		if i == firstStmtIndex {
+			// OK: module() is on top of the file
+			return nil
+		}
+
+		// Move the module() call up and all statements between it and the top down by one position
+		replacements := []LinterReplacement{{&f.Stmt[firstStmtIndex], stmt}}
+		for j := firstStmtIndex; j < i; j++ {
+			replacements = append(replacements, LinterReplacement{&f.Stmt[j+1], f.Stmt[j]})
+		}
+		return []*LinterFinding{makeLinterFinding(call,
+			"The module() declaration should be the first statement of the file.", replacements...)}
+	}
+
+	if firstStmtIndex == -1 {
+		// The file is empty
+		return nil
+	}
+	return []*LinterFinding{makeLinterFinding(f.Stmt[firstStmtIndex],
+		"The file has no module() declaration, it should be the first statement of the file.")}
+}
+
+func moduleOverrideWarning(f *build.File) []*LinterFinding {
+	if f.Type != build.TypeModule {
+		return nil
+	}
+
+	var findings []*LinterFinding
+	for _, c := range moduleCalls(f) {
+		ident, ok := c.call.X.(*build.Ident)
+		if !ok || !strings.HasSuffix(ident.Name, "_override") {
+			continue
+		}
+		findings = append(findings, makeLinterFinding(c.call, fmt.Sprintf(
+			`"%s" only takes effect in the root module and is ignored if the module is used as a dependency.`,
+			ident.Name)))
+	}
+	return findings
+}
+
+func moduleUnknownUseRepoWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
+	if f.Type != build.TypeModule || fileReader == nil {
+		return nil
+	}
+
+	// Repositories created by module extensions, by the names of the variables they're assigned to.
+	// A nil value means the extension can't be analyzed.
+	extensions := make(map[string]map[string]bool)
+	extensionNames := make(map[string]string)
+	var findings []*LinterFinding
+	for stmtIndex, stmt := range f.Stmt {
+		if assign, ok := stmt.(*build.AssignExpr); ok {
+			lhs, ok := assign.LHS.(*build.Ident)
+			if !ok {
+				continue
+			}
+			call, ok := assign.RHS.(*build.CallExpr)
+			if !ok || build.NewRule(call).Kind() != "use_extension" || len(call.List) < 2 {
+				continue
+			}
+			file, ok1 := call.List[0].(*build.StringExpr)
+			name, ok2 := call.List[1].(*build.StringExpr)
+			if !ok1 || !ok2 {
+				continue
+			}
+			extensions[lhs.Name] = extensionRepos(f, fileReader, file.Value, name.Value)
+			extensionNames[lhs.Name] = fmt.Sprintf(`"%s" defined in "%s"`, name.Value, file.Value)
+			continue
+		}
+
+		call, ok := stmt.(*build.CallExpr)
+		if !ok || build.NewRule(call).Kind() != "use_repo" || len(call.List) == 0 {
+			continue
+		}
+		proxy, ok := call.List[0].(*build.Ident)
+		if !ok {
+			continue
+		}
+		repos := extensions[proxy.Name]
+		if repos == nil {
+			continue
+		}
+		unknown := make(map[int]string) // indices of unknown repositories -> their names
+		for i, arg := range call.List {
+			var repo *build.StringExpr
+			switch arg := arg.(type) {
+			case *build.StringExpr:
+				repo = arg
+			case *build.AssignExpr:
+				// use_repo(ext, apparent_name = "repo_name")
+				repo, _ = arg.RHS.(*build.StringExpr)
+			}
+			if i > 0 && repo != nil && !repos[repo.Value] {
+				unknown[i] = repo.Value
+			}
+		}
+		if len(unknown) == 0 {
+			continue
+		}
+
+		// All findings of the same use_repo call share the same fix
+		indices := make(map[int]bool)
+		for i := range unknown {
+			indices[i] = true
+		}
+		replacement := LinterReplacement{&f.Stmt[stmtIndex], callWithoutArgs(call, indices)}
+		for i, arg := range call.List {
+			repo, ok := unknown[i]
+			if !ok {
+				continue
+			}
+			findings = append(findings, makeLinterFinding(arg,
+				fmt.Sprintf(`The repository "%s" is not created by the module extension %s.`,
+					repo, extensionNames[proxy.Name]),
+				replacement))
+		}
+	}
+	return findings
+}
+
+// extensionRepos returns the names of all repositories that are created by a module extension
+// `name` defined in the file `label`. Returns nil if the extension is defined in another
+// repository or not found, or if the names of some of its repositories can't be determined
+// syntactically.
+func extensionRepos(f *build.File, fileReader *FileReader, label, name string) map[string]bool {
+	l := fileReader.resolveLabel(label, f.Pkg)
+	if l.Repository != "" {
+		return nil
+	}
+	extFile := fileReader.GetFile(l.Package, l.Target)
+	if extFile == nil {
+		return nil
+	}
+
+	defs := make(map[string]*build.DefStmt)
+	loaded := make(map[string]bool)
+	var impl string
+	for _, stmt := range extFile.Stmt {
+		switch stmt := stmt.(type) {
+		case *build.DefStmt:
+			defs[stmt.Name] = stmt
+		case *build.LoadStmt:
+			for _, to := range stmt.To {
+				loaded[to.Name] = true
+			}
+		case *build.AssignExpr:
+			lhs, ok := stmt.LHS.(*build.Ident)
+			if !ok || lhs.Name != name {
+				continue
+			}
+			call, ok := stmt.RHS.(*build.CallExpr)
+			if !ok {
+				continue
+			}
+			rule := build.NewRule(call)
+			if rule.Kind() != "module_extension" {
+				continue
+			}
+			implExpr := rule.Attr("implementation")
+			if implExpr == nil && len(call.List) > 0 {
+				if _, ok := call.List[0].(*build.AssignExpr); !ok {
+					implExpr = call.List[0]
+				}
+			}
+			if ident, ok := implExpr.(*build.Ident); ok {
+				impl = ident.Name
+			}
+		}
+	}
+	if defs[impl] == nil {
+		return nil
+	}
+
+	// Collect the `name` arguments of all function calls in the implementation function
+	// and all helper functions from the same file it calls.
+	repos := make(map[string]bool)
+	analyzable := true
+	visited := map[string]bool{impl: true}
+	queue := []string{impl}
+	for len(queue) > 0 && analyzable {
+		def := defs[queue[0]]
+		queue = queue[1:]
+		build.Walk(def, func(expr build.Expr, stack []build.Expr) {
+			call, ok := expr.(*build.CallExpr)
+			if !ok {
+				return
+			}
+			if ident, ok := call.X.(*build.Ident); ok && defs[ident.Name] != nil && !visited[ident.Name] {
+				visited[ident.Name] = true
+				queue = append(queue, ident.Name)
+			}
+			hasName := false
+			for _, arg := range call.List {
+				if unary, ok := arg.(*build.UnaryExpr); ok && unary.Op == "**" {
+					// The arguments can't be analyzed
+					analyzable = false
+				}
+				assign, ok := arg.(*build.AssignExpr)
+				if !ok {
+					continue
+				}
+				if lhs, ok := assign.LHS.(*build.Ident); !ok || lhs.Name != "name" {
+					continue
+				}
+				hasName = true
+				if value, ok := assign.RHS.(*build.StringExpr); ok {
+					repos[value.Value] = true
+				} else {
+					analyzable = false
+				}
+			}
+			if ident, ok := call.X.(*build.Ident); ok && loaded[ident.Name] && !hasName {
+				// A function from another file may create repositories with arbitrary names
+				analyzable = false
+			}
+		})
+	}
+	if !analyzable {
+		return nil
+	}
+	return repos
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warn
+
+import "testing"
+
+func TestModuleDuplicatedDep(t *testing.T) {
+	checkFindingsAndFix(t, "module-duplicated-dep", `
+module(name = "foo")
+
+bazel_dep(name = "rules_go", version = "0.41.0")
+bazel_dep(name = "rules_cc", version = "0.0.9")
+bazel_dep(version = "0.41.0", name = "rules_go")
+bazel_dep(name = "rules_cc", version = "0.0.8")`, `
+module(name = "foo")
+
+bazel_dep(name = "rules_go", version = "0.41.0")
+bazel_dep(name = "rules_cc", version = "0.0.9")
+bazel_dep(name = "rules_cc", version = "0.0.8")`,
+		[]string{
+			`:5: A bazel_dep on "rules_go" is already declared on line 3.`,
+			`:6: A bazel_dep on "rules_cc" is already declared on line 4.`,
+		},
+		scopeModule)
+}
+
+func TestModuleDevDependency(t *testing.T) {
+	checkFindingsAndFix(t, "module-dev-dependency", `
+module(name = "foo", dev_dependency = True)
+
+bazel_dep(name = "rules_go", version = "0.41.0", dev_dependency = False)
+bazel_dep(name = "rules_cc", version = "0.0.9", dev_dependency = True)
+
+ext = use_extension("//:ext.bzl", "ext", dev_dependency = True)
+use_repo(ext, "repo", dev_dependency = True)
+
+single_version_override(module_name = "rules_cc", version = "0.0.9", dev_dependency = True)`, `
+module(name = "foo")
+
+bazel_dep(name = "rules_go", version = "0.41.0")
+bazel_dep(name = "rules_cc", version = "0.0.9", dev_dependency = True)
+
+ext = use_extension("//:ext.bzl", "ext", dev_dependency = True)
+use_repo(ext, "repo")
+
+single_version_override(module_name = "rules_cc", version = "0.0.9")`,
+		[]string{
+			`:1: The function "module" doesn't accept the "dev_dependency" parameter.`,
+			`:3: "dev_dependency = False" is the default value and can be omitted.`,
+			`:7: The function "use_repo" doesn't accept the "dev_dependency" parameter.`,
+			`:9: The function "single_version_override" doesn't accept the "dev_dependency" parameter.`,
+		},
+		scopeModule)
+}
+
+func TestModuleUnsortedDep(t *testing.T) {
+	checkFindingsAndFix(t, "module-unsorted-dep", `
+module(name = "foo")
+
+bazel_dep(name = "rules_go", version = "0.41.0")
+# rules_cc comment
+bazel_dep(name = "rules_cc", version = "0.0.9")
+bazel_dep(name = "bazel_skylib", version = "1.4.2")
+
+bazel_dep(name = "zlib", version = "1.3")
+
+ext = use_extension("//:ext.bzl", "ext")
+
+bazel_dep(name = "b", version = "1.0")
+bazel_dep(name = "a", version = "1.0")`, `
+module(name = "foo")
+
+bazel_dep(name = "bazel_skylib", version = "1.4.2")
+
+# rules_cc comment
+bazel_dep(name = "rules_cc", version = "0.0.9")
+bazel_dep(name = "rules_go", version = "0.41.0")
+bazel_dep(name = "zlib", version = "1.3")
+
+ext = use_extension("//:ext.bzl", "ext")
+
+bazel_dep(name = "a", version = "1.0")
+bazel_dep(name = "b", version = "1.0")`,
+		[]string{
+			`:5: The bazel_dep on "rules_cc" is out of its lexicographical order.`,
+			`:6: The bazel_dep on "bazel_skylib" is out of its lexicographical order.`,
+			`:13: The bazel_dep on "a" is out of its lexicographical order.`,
+		},
+		scopeModule)
+}
+
+func TestModuleOnTop(t *testing.T) {
+	checkFindingsAndFix(t, "module-on-top", `
+# Copyright notice
+
+bazel_dep(name = "rules_go", version = "0.41.0")
+
+module(name = "foo", version = "1.0")
+
+bazel_dep(name = "rules_cc", version = "0.0.9")`, `
+# Copyright notice
+
+module(name = "foo", version = "1.0")
+
+bazel_dep(name = "rules_go", version = "0.41.0")
+bazel_dep(name = "rules_cc", version = "0.0.9")`,
+		[]string{":5: The module() declaration should be the first statement of the file."},
+		scopeModule)
+
+	checkFindings(t, "module-on-top", `
+# Copyright notice
+
+bazel_dep(name = "rules_go", version = "0.41.0")`,
+		[]string{":3: The file has no module() declaration, it should be the first statement of the file."},
+		scopeModule)
+
+	checkFindings(t, "module-on-top", `
+# Copyright notice
+
+module(name = "foo", version = "1.0")
+
+bazel_dep(name = "rules_go", version = "0.41.0")`,
+		[]string{},
+		scopeModule)
+}
+
+func TestModuleOverride(t *testing.T) {
+	checkFindings(t, "module-override", `
+module(name = "foo", version = "1.0")
+
+bazel_dep(name = "rules_go", version = "0.41.0")
+
+single_version_override(module_name = "rules_go", version = "0.41.0")
+git_override(module_name = "rules_cc", remote = "https://example.com/rules_cc.git")`,
+		[]string{
+			`:5: "single_version_override" only takes effect in the root module and is ignored if the module is used as a dependency.`,
+			`:6: "git_override" only takes effect in the root module and is ignored if the module is used as a dependency.`,
+		},
+		scopeModule)
+}
+
+func TestModuleUnknownUseRepo(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"test/package/ext.bzl": `
+load("@bazel_tools//tools/build_defs/repo:http.bzl", "http_archive")
+
+def _create_repos(suffix):
+    http_archive(name = "helper_repo", url = "https://example.com/" + suffix)
+
+def _impl(module_ctx):
+    http_archive(name = "foo", url = "https://example.com/foo")
+    http_archive(name = "bar", url = "https://example.com/bar")
+    _create_repos("baz")
+
+ext = module_extension(implementation = _impl)
+`,
+		"test/package/dynamic.bzl": `
+load("@bazel_tools//tools/build_defs/repo:http.bzl", "http_archive")
+
+def _impl(module_ctx):
+    for mod in module_ctx.modules:
+        http_archive(name = mod.name)
+
+dynamic = module_extension(_impl)
+`,
+		"test/package/macro.bzl": `
+load(":repos.bzl", "create_repos")
+
+def _impl(module_ctx):
+    create_repos()
+
+macro = module_extension(implementation = _impl)
+`,
+	})()
+
+	checkFindingsAndFix(t, "module-unknown-use-repo", `
+ext = use_extension("//test/package:ext.bzl", "ext")
+use_repo(ext, "foo", "unknown", "helper_repo", my_bar = "bar", my_baz = "baz")
+
+dynamic = use_extension(":dynamic.bzl", "dynamic")
+use_repo(dynamic, "foo", "unknown")
+
+macro = use_extension(":macro.bzl", "macro")
+use_repo(macro, "foo", "unknown")
+
+external = use_extension("@rules_go//go:extensions.bzl", "go_sdk")
+use_repo(external, "unknown")
+
+missing = use_extension(":missing.bzl", "missing")
+use_repo(missing, "unknown")`, `
+ext = use_extension("//test/package:ext.bzl", "ext")
+use_repo(ext, "foo", "helper_repo", my_bar = "bar")
+
+dynamic = use_extension(":dynamic.bzl", "dynamic")
+use_repo(dynamic, "foo", "unknown")
+
+macro = use_extension(":macro.bzl", "macro")
+use_repo(macro, "foo", "unknown")
+
+external = use_extension("@rules_go//go:extensions.bzl", "go_sdk")
+use_repo(external, "unknown")
+
+missing = use_extension(":missing.bzl", "missing")
+use_repo(missing, "unknown")`,
+		[]string{
+			`:2: The repository "unknown" is not created by the module extension "ext" defined in "//test/package:ext.bzl".`,
+			`:2: The repository "baz" is not created by the module extension "ext" defined in "//test/package:ext.bzl".`,
+		},
+		scopeModule)
+
+	checkFindingsAndFix(t, "module-unknown-use-repo", `
+ext = use_extension("//test/package:ext.bzl", "ext")
+use_repo(ext, "foo", "bar", my_baz = "baz")`, `
+ext = use_extension("//test/package:ext.bzl", "ext")
+use_repo(ext, "foo", "bar")`,
+		[]string{
+			`:2: The repository "baz" is not created by the module extension "ext" defined in "//test/package:ext.bzl".`,
+		},
+		scopeModule)
+}
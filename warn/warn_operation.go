@@ -22,7 +22,7 @@ import (
 	"github.com/bazelbuild/buildtools/build"
 )
 
-func dictionaryConcatenationWarning(f *build.File) []*LinterFinding {
+func dictionaryConcatenationWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
 	var findings []*LinterFinding
 
 	var addWarning = func(expr build.Expr) {
@@ -30,21 +30,21 @@ func dictionaryConcatenationWarning(f *build.File) []*LinterFinding {
 			makeLinterFinding(expr, "Dictionary concatenation is deprecated."))
 	}
 
-	types := detectTypes(f)
+	info := fileReader.typeInfo(f)
 	build.Walk(f, func(expr build.Expr, stack []build.Expr) {
 		switch expr := expr.(type) {
 		case *build.BinaryExpr:
 			if expr.Op != "+" {
 				return
 			}
-			if types[expr.X] == Dict || types[expr.Y] == Dict {
+			if info.TypeOf(expr.X) == Dict || info.TypeOf(expr.Y) == Dict {
 				addWarning(expr)
 			}
 		case *build.AssignExpr:
 			if expr.Op != "+=" {
 				return
 			}
-			if types[expr.LHS] == Dict || types[expr.RHS] == Dict {
+			if info.TypeOf(expr.LHS) == Dict || info.TypeOf(expr.RHS) == Dict {
 				addWarning(expr)
 			}
 		}
@@ -52,7 +52,7 @@ func dictionaryConcatenationWarning(f *build.File) []*LinterFinding {
 	return findings
 }
 
-func stringIterationWarning(f *build.File) []*LinterFinding {
+func stringIterationWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
 	var findings []*LinterFinding
 
 	addWarning := func(expr build.Expr) {
@@ -60,15 +60,15 @@ func stringIterationWarning(f *build.File) []*LinterFinding {
 			makeLinterFinding(expr, "String iteration is deprecated."))
 	}
 
-	types := detectTypes(f)
+	info := fileReader.typeInfo(f)
 	build.Walk(f, func(expr build.Expr, stack []build.Expr) {
 		switch expr := expr.(type) {
 		case *build.ForStmt:
-			if types[expr.X] == String {
+			if info.TypeOf(expr.X) == String {
 				addWarning(expr.X)
 			}
 		case *build.ForClause:
-			if types[expr.X] == String {
+			if info.TypeOf(expr.X) == String {
 				addWarning(expr.X)
 			}
 		case *build.CallExpr:
@@ -81,12 +81,12 @@ func stringIterationWarning(f *build.File) []*LinterFinding {
 				if len(expr.List) != 1 {
 					return
 				}
-				if types[expr.List[0]] == String {
+				if info.TypeOf(expr.List[0]) == String {
 					addWarning(expr.List[0])
 				}
 			case "zip":
 				for _, arg := range expr.List {
-					if types[arg] == String {
+					if info.TypeOf(arg) == String {
 						addWarning(arg)
 					}
 				}
@@ -96,17 +96,17 @@ func stringIterationWarning(f *build.File) []*LinterFinding {
 	return findings
 }
 
-func integerDivisionWarning(f *build.File) []*LinterFinding {
+func integerDivisionWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
 	var findings []*LinterFinding
 
-	types := detectTypes(f)
+	info := fileReader.typeInfo(f)
 	build.WalkPointers(f, func(e *build.Expr, stack []build.Expr) {
 		switch expr := (*e).(type) {
 		case *build.BinaryExpr:
 			if expr.Op != "/" {
 				return
 			}
-			if types[expr.X] != Int || types[expr.Y] != Int {
+			if info.TypeOf(expr.X) != Int || info.TypeOf(expr.Y) != Int {
 				return
 			}
 			newBinary := *expr
@@ -119,7 +119,7 @@ func integerDivisionWarning(f *build.File) []*LinterFinding {
 			if expr.Op != "/=" {
 				return
 			}
-			if types[expr.LHS] != Int || types[expr.RHS] != Int {
+			if info.TypeOf(expr.LHS) != Int || info.TypeOf(expr.RHS) != Int {
 				return
 			}
 			newAssign := *expr
@@ -16,7 +16,12 @@ limitations under the License.
 
 package warn
 
-import "testing"
+import (
+	"os"
+	"testing"
+
+	"github.com/bazelbuild/buildtools/build"
+)
 
 func TestIntegerDivision(t *testing.T) {
 	checkFindingsAndFix(t, "integer-division", `
@@ -120,6 +125,43 @@ for x in l:
 		scopeEverywhere)
 }
 
+func TestStringIterationInferredTypes(t *testing.T) {
+	defer setUpFileReader(map[string]string{
+		"test/package/names.bzl": `
+PREFIX = "foo"
+
+def get_name(x):
+    return PREFIX + x
+`,
+	})()
+
+	checkFindings(t, "string-iteration", `
+load(":names.bzl", "PREFIX", "get_name")
+
+def _impl(rule_ctx):
+    for x in rule_ctx.label.name:
+        pass
+    for x in get_name("bar").split("/"):
+        pass
+
+def f():
+    return "foo".upper()
+
+max(f())
+min(PREFIX)
+any(get_name("bar"))
+
+foo = rule(implementation = _impl)
+`,
+		[]string{
+			":4: String iteration is deprecated.",
+			":12: String iteration is deprecated.",
+			":13: String iteration is deprecated.",
+			":14: String iteration is deprecated.",
+		},
+		scopeEverywhere)
+}
+
 func TestListAppend(t *testing.T) {
 	checkFindingsAndFix(t, "list-append", `
 x = []
@@ -150,3 +192,26 @@ x.append(foo(
 		},
 		scopeEverywhere)
 }
+
+func TestTypeInfoCache(t *testing.T) {
+	fileReader := NewFileReader(func(filename string) ([]byte, error) {
+		return nil, os.ErrNotExist
+	})
+	f, err := build.ParseBzl("test.bzl", []byte("a = 1\nb = a / 2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := fileReader.typeInfo(f)
+	if fileReader.typeInfo(f) != info {
+		t.Error("typeInfo() analyzed the file again, want the cached types")
+	}
+
+	FixWarnings(f, []string{"integer-division"}, false, fileReader)
+	if got := string(build.Format(f)); got != "a = 1\nb = a // 2\n" {
+		t.Errorf("FixWarnings() = %q", got)
+	}
+	if fileReader.typeInfo(f) == info {
+		t.Error("typeInfo() returned the cached types after the file was fixed")
+	}
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Declarative policies for BUILD files read from a config file
+
+package warn
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/labels"
+	"github.com/bazelbuild/buildtools/tables"
+)
+
+// PolicyConfig is the content of a JSON file with policies checked by the "policy" warning.
+type PolicyConfig struct {
+	Policies []Policy `json:"policies"`
+}
+
+// Policy describes requirements for rules in BUILD files.
+type Policy struct {
+	Name    string `json:"name"`    // name of the policy, used in warning messages
+	Message string `json:"message"` // optional explanation, used in warning messages
+
+	// Kinds are the glob patterns of rule kinds the policy applies to (e.g. "java_*").
+	// The policy applies to all rules if empty.
+	Kinds []string `json:"kinds"`
+	// Packages are the patterns of packages the policy applies to (e.g. "//base/...").
+	// The policy applies to all packages if empty.
+	Packages []string `json:"packages"`
+
+	RequiredAttrs  []string          `json:"required_attrs"`  // attributes that should be set
+	ForbiddenAttrs []string          `json:"forbidden_attrs"` // attributes that shouldn't be set
+	AttrValues     []AttrValuePolicy `json:"attr_values"`     // restrictions for string values of attributes
+	Labels         []LabelPolicy     `json:"labels"`          // restrictions for labels
+}
+
+// AttrValuePolicy restricts all string values of an attribute (including values in lists).
+type AttrValuePolicy struct {
+	Attr     string `json:"attr"`
+	Match    string `json:"match"`     // regular expression all values should match
+	NotMatch string `json:"not_match"` // regular expression no value should match
+}
+
+// LabelPolicy restricts the labels used in attributes of a rule. Label patterns can be exact
+// labels ("//foo:bar"), all targets of a package ("//foo:*" or "//foo:all")
+// or all targets of a package and its subpackages ("//foo/...").
+type LabelPolicy struct {
+	// Attrs are the attributes to check. If empty, all attributes known to contain labels
+	// (e.g. "deps" or "srcs") are checked. Values that refer to source files of the package
+	// (e.g. "a.cc", but not "foo" or ":foo" if the package declares a target "foo") are skipped.
+	Attrs []string `json:"attrs"`
+	Allow []string `json:"allow"` // if not empty, all labels should match one of these patterns
+	Deny  []string `json:"deny"`  // labels shouldn't match any of these patterns
+}
+
+// compiledAttrValuePolicy is an AttrValuePolicy with compiled regular expressions.
+type compiledAttrValuePolicy struct {
+	attr     string
+	match    *regexp.Regexp
+	notMatch *regexp.Regexp
+}
+
+// compiledPolicy is a Policy with compiled regular expressions.
+type compiledPolicy struct {
+	Policy
+	attrValues []compiledAttrValuePolicy
+}
+
+// policies are the currently used policies.
+var policies []compiledPolicy
+
+// ParsePolicyFile reads policies from a JSON file and replaces the currently used policies.
+func ParsePolicyFile(file string) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var config PolicyConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+	return SetPolicies(config.Policies)
+}
+
+// SetPolicies replaces the currently used policies.
+func SetPolicies(newPolicies []Policy) error {
+	var compiled []compiledPolicy
+	for _, p := range newPolicies {
+		c := compiledPolicy{Policy: p}
+		for _, v := range p.AttrValues {
+			cv := compiledAttrValuePolicy{attr: v.Attr}
+			var err error
+			if v.Match != "" {
+				if cv.match, err = regexp.Compile(v.Match); err != nil {
+					return fmt.Errorf("policy %q: %v", p.Name, err)
+				}
+			}
+			if v.NotMatch != "" {
+				if cv.notMatch, err = regexp.Compile(v.NotMatch); err != nil {
+					return fmt.Errorf("policy %q: %v", p.Name, err)
+				}
+			}
+			c.attrValues = append(c.attrValues, cv)
+		}
+		compiled = append(compiled, c)
+	}
+	policies = compiled
+	return nil
+}
+
+// matchPackagePattern checks whether a package matches a pattern, e.g. "//foo/..." matches
+// "foo" and all its subpackages, "//foo/*" matches direct subpackages of "foo",
+// "//*/internal/..." matches all packages inside "internal" directories on the second level.
+func matchPackagePattern(pattern, pkg string) bool {
+	pattern = strings.TrimPrefix(pattern, "//")
+	if pattern == "..." {
+		return true
+	}
+	if !strings.HasSuffix(pattern, "/...") {
+		matched, _ := path.Match(pattern, pkg)
+		return matched
+	}
+	// The package or one of its parents should match the prefix
+	prefix := strings.TrimSuffix(pattern, "/...")
+	parts := strings.Split(pkg, "/")
+	for i := range parts {
+		if matched, _ := path.Match(prefix, strings.Join(parts[:i+1], "/")); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchLabelPattern checks whether a label matches a pattern (see LabelPolicy).
+func matchLabelPattern(pattern string, label labels.Label) bool {
+	repo := ""
+	if strings.HasPrefix(pattern, "@") {
+		i := strings.Index(pattern, "//")
+		if i < 0 {
+			return false
+		}
+		repo, pattern = strings.TrimLeft(pattern[:i], "@"), pattern[i:]
+	}
+	if repo != label.Repository {
+		return false
+	}
+	pkgPattern, targetPattern := pattern, "*"
+	if i := strings.Index(pattern, ":"); i >= 0 {
+		pkgPattern, targetPattern = pattern[:i], pattern[i+1:]
+	} else if !strings.HasSuffix(pattern, "...") {
+		// "//foo" is the same as "//foo:foo"
+		targetPattern = path.Base(strings.TrimPrefix(pattern, "//"))
+	}
+	if !matchPackagePattern(pkgPattern, label.Package) {
+		return false
+	}
+	if targetPattern == "all" {
+		return true
+	}
+	matched, _ := path.Match(targetPattern, label.Target)
+	return matched
+}
+
+func matchAny(patterns []string, match func(string) bool) bool {
+	for _, p := range patterns {
+		if match(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// applies checks whether the policy applies to a rule in a package.
+func (p *compiledPolicy) applies(rule *build.Rule, pkg string) bool {
+	if len(p.Kinds) > 0 && !matchAny(p.Kinds, func(k string) bool {
+		matched, _ := path.Match(k, rule.Kind())
+		return matched
+	}) {
+		return false
+	}
+	if len(p.Packages) > 0 && !matchAny(p.Packages, func(pattern string) bool {
+		return matchPackagePattern(pattern, pkg)
+	}) {
+		return false
+	}
+	return true
+}
+
+// message formats a warning message for a violation of the policy.
+func (p *compiledPolicy) message(rule *build.Rule, detail string) string {
+	name := rule.Name()
+	if name == "" {
+		name = rule.Kind()
+	}
+	msg := fmt.Sprintf("%q %s", name, detail)
+	switch {
+	case p.Message != "":
+		return p.Message + ": " + msg + "."
+	case p.Name != "":
+		return fmt.Sprintf("Policy %q is violated: %s.", p.Name, msg)
+	}
+	return fmt.Sprintf("Policy is violated: %s.", msg)
+}
+
+// stringValues returns all string literals of an expression (including the ones
+// in lists and select statements).
+func stringValues(expr build.Expr) []*build.StringExpr {
+	var result []*build.StringExpr
+	build.Walk(expr, func(x build.Expr, stk []build.Expr) {
+		if str, ok := x.(*build.StringExpr); ok {
+			result = append(result, str)
+		}
+	})
+	return result
+}
+
+// isSourceFile returns whether a label-like value refers to a file of the current package
+// (e.g. "a.cc", ":sub/b.h") rather than to one of the targets declared in it.
+func isSourceFile(value string, label labels.Label, targets map[string]bool) bool {
+	if strings.HasPrefix(value, "//") || strings.HasPrefix(value, "@") {
+		return false
+	}
+	return !targets[label.Target]
+}
+
+func (p *compiledPolicy) check(rule *build.Rule, pkg string, targets map[string]bool) []*LinterFinding {
+	var findings []*LinterFinding
+
+	for _, attr := range p.RequiredAttrs {
+		if rule.Attr(attr) == nil {
+			findings = append(findings, makeLinterFinding(rule.Call,
+				p.message(rule, fmt.Sprintf("should set the %q attribute", attr))))
+		}
+	}
+	for _, attr := range p.ForbiddenAttrs {
+		if as := rule.AttrDefn(attr); as != nil {
+			findings = append(findings, makeLinterFinding(as,
+				p.message(rule, fmt.Sprintf("shouldn't set the %q attribute", attr))))
+		}
+	}
+	for _, v := range p.attrValues {
+		for _, str := range stringValues(rule.Attr(v.attr)) {
+			if v.match != nil && !v.match.MatchString(str.Value) {
+				findings = append(findings, makeLinterFinding(str, p.message(rule,
+					fmt.Sprintf("has a value %q of the %q attribute that doesn't match %q", str.Value, v.attr, v.match))))
+			}
+			if v.notMatch != nil && v.notMatch.MatchString(str.Value) {
+				findings = append(findings, makeLinterFinding(str, p.message(rule,
+					fmt.Sprintf("has a value %q of the %q attribute that matches %q", str.Value, v.attr, v.notMatch))))
+			}
+		}
+	}
+	for _, l := range p.Labels {
+		attrs := l.Attrs
+		if len(attrs) == 0 {
+			for _, attr := range rule.AttrKeys() {
+				if tables.IsLabelArg[attr] && !tables.LabelDenylist[rule.Kind()+"."+attr] {
+					attrs = append(attrs, attr)
+				}
+			}
+			sort.Strings(attrs)
+		}
+		for _, attr := range attrs {
+			for _, str := range stringValues(rule.Attr(attr)) {
+				label := labels.ParseRelative(str.Value, pkg)
+				if isSourceFile(str.Value, label, targets) {
+					continue
+				}
+				matches := func(pattern string) bool { return matchLabelPattern(pattern, label) }
+				if matchAny(l.Deny, matches) || (len(l.Allow) > 0 && !matchAny(l.Allow, matches)) {
+					findings = append(findings, makeLinterFinding(str, p.message(rule,
+						fmt.Sprintf("shouldn't depend on %q in the %q attribute", str.Value, attr))))
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func policyWarning(f *build.File) []*LinterFinding {
+	if f.Type != build.TypeBuild || len(policies) == 0 {
+		return nil
+	}
+
+	rules := f.Rules("")
+	targets := make(map[string]bool)
+	for _, rule := range rules {
+		if name := rule.Name(); name != "" {
+			targets[name] = true
+		}
+	}
+
+	var findings []*LinterFinding
+	for _, rule := range rules {
+		for i := range policies {
+			if policies[i].applies(rule, f.Pkg) {
+				findings = append(findings, policies[i].check(rule, f.Pkg, targets)...)
+			}
+		}
+	}
+	return findings
+}
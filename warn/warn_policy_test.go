@@ -0,0 +1,228 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warn
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bazelbuild/buildtools/labels"
+)
+
+func setUpPolicies(t *testing.T, config string) (cleanup func()) {
+	dir, err := ioutil.TempDir(os.Getenv("TEST_TMPDIR"), "policy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "policy.json")
+	if err := ioutil.WriteFile(file, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ParsePolicyFile(file); err != nil {
+		t.Fatal(err)
+	}
+	return func() {
+		policies = nil
+		os.RemoveAll(dir)
+	}
+}
+
+func TestPolicyAttrs(t *testing.T) {
+	defer setUpPolicies(t, `{
+  "policies": [
+    {
+      "name": "java-test-size",
+      "kinds": ["java_test"],
+      "required_attrs": ["size"]
+    },
+    {
+      "message": "Rules under //test shouldn't be tested locally",
+      "packages": ["//test/..."],
+      "attr_values": [{"attr": "tags", "not_match": "^local$"}]
+    },
+    {
+      "name": "cc-names",
+      "kinds": ["cc_*"],
+      "forbidden_attrs": ["linkstatic"],
+      "attr_values": [{"attr": "name", "match": "^[a-z_]+$"}]
+    },
+    {
+      "name": "other-package",
+      "packages": ["//other/..."],
+      "required_attrs": ["visibility"]
+    }
+  ]
+}`)()
+
+	checkFindings(t, "policy", `
+java_test(
+    name = "a",
+    size = "small",
+)
+
+java_test(
+    name = "b",
+    tags = ["local", "manual"],
+)
+
+cc_library(
+    name = "C",
+    linkstatic = True,
+)`,
+		[]string{
+			`:6: Policy "java-test-size" is violated: "b" should set the "size" attribute.`,
+			`:8: Rules under //test shouldn't be tested locally: "b" has a value "local" of the "tags" attribute that matches "^local$".`,
+			`:12: Policy "cc-names" is violated: "C" has a value "C" of the "name" attribute that doesn't match "^[a-z_]+$".`,
+			`:13: Policy "cc-names" is violated: "C" shouldn't set the "linkstatic" attribute.`,
+		},
+		scopeBuild)
+
+	checkFindings(t, "policy", `
+java_test(
+    name = "b",
+    # buildifier: disable=policy
+    tags = ["local"],
+)  # buildifier: disable=policy`,
+		[]string{},
+		scopeBuild)
+}
+
+func TestPolicyLabels(t *testing.T) {
+	defer setUpPolicies(t, `{
+  "policies": [
+    {
+      "name": "no-legacy",
+      "labels": [{"deny": ["//legacy/...", "@old_repo//..."]}]
+    },
+    {
+      "name": "restricted-runtime-deps",
+      "kinds": ["java_library"],
+      "labels": [{"attrs": ["runtime_deps"], "allow": ["//test/package:*", "//third_party/java/..."]}]
+    }
+  ]
+}`)()
+
+	checkFindings(t, "policy", `
+java_library(
+    name = "a",
+    srcs = ["A.java"],
+    deps = [
+        ":b",
+        "//legacy",
+        "//legacy/foo:bar",
+        "//legacyfoo:bar",
+        "@old_repo//foo",
+    ],
+    runtime_deps = [
+        ":c",
+        "//third_party/java/guava",
+        "//other:d",
+    ],
+)`,
+		[]string{
+			`:6: Policy "no-legacy" is violated: "a" shouldn't depend on "//legacy" in the "deps" attribute.`,
+			`:7: Policy "no-legacy" is violated: "a" shouldn't depend on "//legacy/foo:bar" in the "deps" attribute.`,
+			`:9: Policy "no-legacy" is violated: "a" shouldn't depend on "@old_repo//foo" in the "deps" attribute.`,
+			`:14: Policy "restricted-runtime-deps" is violated: "a" shouldn't depend on "//other:d" in the "runtime_deps" attribute.`,
+		},
+		scopeBuild)
+}
+
+func TestPolicyLabelsSourceFiles(t *testing.T) {
+	defer setUpPolicies(t, `{
+  "policies": [
+    {
+      "name": "allowed-deps",
+      "labels": [{"allow": ["//test/package:*", "//base/..."]}]
+    },
+    {
+      "name": "allowed-data",
+      "labels": [{"attrs": ["data"], "allow": ["//base/..."]}]
+    },
+    {
+      "name": "no-gen",
+      "kinds": ["cc_library"],
+      "labels": [{"deny": ["//test/package:gen"]}]
+    }
+  ]
+}`)()
+
+	checkFindings(t, "policy", `
+cc_library(
+    name = "a",
+    srcs = [
+        "a.cc",
+        "sub/b.cc",
+        "//other:c.cc",
+    ],
+    data = ["a.txt", ":gen"],
+    deps = [
+        ":b.h",
+        "gen",
+        "//base",
+        "@other_repo//:d",
+    ],
+)
+
+genrule(name = "gen")`,
+		[]string{
+			`:6: Policy "allowed-deps" is violated: "a" shouldn't depend on "//other:c.cc" in the "srcs" attribute.`,
+			`:8: Policy "allowed-data" is violated: "a" shouldn't depend on ":gen" in the "data" attribute.`,
+			`:8: Policy "no-gen" is violated: "a" shouldn't depend on ":gen" in the "data" attribute.`,
+			`:11: Policy "no-gen" is violated: "a" shouldn't depend on "gen" in the "deps" attribute.`,
+			`:13: Policy "allowed-deps" is violated: "a" shouldn't depend on "@other_repo//:d" in the "deps" attribute.`,
+		},
+		scopeBuild)
+}
+
+func TestPolicyInvalidRegex(t *testing.T) {
+	defer func() { policies = nil }()
+	if err := SetPolicies([]Policy{{Name: "x", AttrValues: []AttrValuePolicy{{Attr: "name", Match: "("}}}}); err == nil {
+		t.Error("SetPolicies() with an invalid regular expression returned no error")
+	}
+}
+
+func TestMatchLabelPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		label   string
+		match   bool
+	}{
+		{"//foo:bar", "//foo:bar", true},
+		{"//foo:bar", "//foo:baz", false},
+		{"//foo", "//foo:foo", true},
+		{"//foo", "//foo:bar", false},
+		{"//foo:*", "//foo:bar", true},
+		{"//foo:all", "//foo:bar", true},
+		{"//foo:*", "//foo/bar:baz", false},
+		{"//foo/...", "//foo", true},
+		{"//foo/...", "//foo/bar:baz", true},
+		{"//foo/...", "//foobar:baz", false},
+		{"//...", "//foo:bar", true},
+		{"//...", "@repo//foo:bar", false},
+		{"@repo//...", "@repo//foo:bar", true},
+		{"//*/internal/...", "//foo/internal/bar", true},
+		{"//foo:*_test", "//foo:bar_test", true},
+	}
+	for _, tc := range tests {
+		if got := matchLabelPattern(tc.pattern, labels.Parse(tc.label)); got != tc.match {
+			t.Errorf("matchLabelPattern(%q, %q) = %v, want %v", tc.pattern, tc.label, got, tc.match)
+		}
+	}
+}
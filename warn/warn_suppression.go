@@ -0,0 +1,118 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Warnings about comments that disable other warnings
+
+package warn
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+)
+
+// unusedSuppressionCategory is the name of the warning about unused suppression comments.
+// It depends on the findings of other warnings, so FileWarnings runs it after all of them.
+const unusedSuppressionCategory = "unused-suppression"
+
+var suppressionRegexp = regexp.MustCompile(`(?i)\b(?:buildifier|buildozer): disable(?:-file|-next-line|-start|-end)?=([^\s,]+)`)
+
+// withoutComments returns a shallow copy of a node without the comments starting at the given
+// positions, or nil if the node is a comment block that has no comments left.
+func withoutComments(expr build.Expr, remove map[build.Position]bool) build.Expr {
+	filter := func(comments []build.Comment) []build.Comment {
+		var result []build.Comment
+		for _, c := range comments {
+			if !remove[c.Start] {
+				result = append(result, c)
+			}
+		}
+		return result
+	}
+
+	result := expr.Copy()
+	com := result.Comment()
+	com.Before = filter(com.Before)
+	com.Suffix = filter(com.Suffix)
+	com.After = filter(com.After)
+	if _, ok := result.(*build.CommentBlock); ok && len(com.Before)+len(com.Suffix)+len(com.After) == 0 {
+		return nil
+	}
+	return result
+}
+
+// suppressionWarning reports comments that disable unknown warnings, and comments that disable
+// warnings that have run on the file but haven't suppressed any findings (their positions are not
+// in usedSuppressions). Warnings that haven't run (e.g. multi-file warnings without a FileReader)
+// are not reported because it's unknown whether their suppressions are needed.
+func suppressionWarning(f *build.File, ran map[string]bool, usedSuppressions map[build.Position]bool) []*LinterFinding {
+	known := make(map[string]bool)
+	for _, w := range AllWarnings {
+		known[w] = true
+	}
+
+	var findings []*LinterFinding
+	build.WalkPointers(f, func(x *build.Expr, stk []build.Expr) {
+		// Nodes that are not stored in the syntax tree directly can't be replaced
+		fixable := len(stk) > 0
+		switch (*x).(type) {
+		case *build.KeyValueExpr, *build.ForClause, *build.IfClause:
+			fixable = false
+		}
+
+		var nodeFindings []*LinterFinding
+		remove := make(map[build.Position]bool)
+		com := (*x).Comment()
+		for _, comments := range [][]build.Comment{com.Before, com.Suffix, com.After} {
+			for _, c := range comments {
+				matches := suppressionRegexp.FindAllStringSubmatch(c.Token, -1)
+				var commentFindings []*LinterFinding
+				for _, m := range matches {
+					category := strings.ToLower(m[1])
+					var msg string
+					switch {
+					case !known[category]:
+						msg = fmt.Sprintf("Unknown warning category %q in the suppression comment.", category)
+					case ran[category] && !usedSuppressions[c.Start]:
+						msg = fmt.Sprintf("The suppression comment for %q doesn't suppress any warnings.", category)
+					default:
+						continue
+					}
+					start, end := c.Span()
+					commentFindings = append(commentFindings, &LinterFinding{Start: start, End: end, Message: msg})
+				}
+				if len(commentFindings) > 0 && len(commentFindings) == len(matches) {
+					// The comment is removed only if none of its suppressions are useful
+					remove[c.Start] = true
+				}
+				nodeFindings = append(nodeFindings, commentFindings...)
+			}
+		}
+
+		if fixable && len(remove) > 0 {
+			replacement := []LinterReplacement{{x, withoutComments(*x, remove)}}
+			for _, finding := range nodeFindings {
+				if remove[finding.Start] {
+					finding.Replacement = replacement
+				}
+			}
+		}
+		findings = append(findings, nodeFindings...)
+	})
+	return findings
+}
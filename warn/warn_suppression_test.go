@@ -0,0 +1,184 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warn
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bazelbuild/buildtools/build"
+)
+
+func TestUnknownSuppression(t *testing.T) {
+	checkFindingsAndFix(t, "unused-suppression", `
+# buildifier: disable=load-on-tops
+load(":foo.bzl", "foo")
+
+foo(
+    name = "x",  # buildozer: disable=positional-args
+    srcs = ["a"],  # buildifier: disable=unknown-category
+)
+
+# Some explanation
+# buildifier: disable=skylark-coment
+x = 1  # buildifier: disable=native-cc`, `
+load(":foo.bzl", "foo")
+
+foo(
+    name = "x",  # buildozer: disable=positional-args
+    srcs = ["a"],
+)
+
+# Some explanation
+x = 1  # buildifier: disable=native-cc`,
+		[]string{
+			`:1: Unknown warning category "load-on-tops" in the suppression comment.`,
+			`:6: Unknown warning category "unknown-category" in the suppression comment.`,
+			`:10: Unknown warning category "skylark-coment" in the suppression comment.`,
+		},
+		scopeEverywhere)
+
+	checkFindingsAndFix(t, "unused-suppression", `
+x = 1  # buildifier: disable=typo
+
+# buildifier: disable=unused-suppression
+y = 2  # buildifier: disable=typo`, `
+x = 1
+
+# buildifier: disable=unused-suppression
+y = 2  # buildifier: disable=typo`,
+		[]string{`:1: Unknown warning category "typo" in the suppression comment.`},
+		scopeEverywhere)
+}
+
+func TestUnusedSuppression(t *testing.T) {
+	input := `
+def f():
+    # buildifier: disable=print
+    print("foo")  # buildifier: disable=print
+
+    # buildifier: disable=print
+    return 1
+
+    # buildifier: disable=unreachable
+    pass
+
+    # buildifier: disable=no-effect
+    "foo"
+
+# buildifier: disable=no-effect
+y = 1
+
+x = {
+    "b": 1,  # buildifier: disable=unsorted-dict-items
+    "a": 2,
+}
+`
+	f, err := build.ParseBzl("test_file.bzl", []byte(strings.TrimLeft(input, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	warnings := []string{"no-effect", "print", "unreachable", "unused-suppression"}
+
+	var findings []string
+	for _, finding := range FileWarnings(f, warnings, nil, ModeWarn, nil) {
+		if finding.Category == "unused-suppression" {
+			findings = append(findings, fmt.Sprintf(":%d: %s", finding.Start.Line, finding.Message))
+		}
+	}
+	want := []string{
+		`:5: The suppression comment for "print" doesn't suppress any warnings.`,
+		`:14: The suppression comment for "no-effect" doesn't suppress any warnings.`,
+	}
+	if strings.Join(findings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got findings:\n%s\nwant:\n%s", strings.Join(findings, "\n"), strings.Join(want, "\n"))
+	}
+
+	FixWarnings(f, warnings, false, nil)
+	fixed := string(build.Format(f))
+	if strings.Count(fixed, "disable=no-effect") != 1 || strings.Count(fixed, "disable=print") != 2 {
+		t.Errorf("unexpected fixed file:\n%s", fixed)
+	}
+}
+
+func TestUnusedSuppressionDirectives(t *testing.T) {
+	input := `# buildifier: disable-file=print
+# buildifier: disable-file=no-effect
+
+def f():
+    print("foo")
+
+# buildifier: disable-next-line=print
+x = 1
+
+# buildifier: disable-start=no-effect
+y = 2
+# buildifier: disable-end=no-effect
+
+# buildifier: disable-end=print
+`
+	f, err := build.ParseBzl("test_file.bzl", []byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var findings []string
+	for _, finding := range FileWarnings(f, []string{"no-effect", "print", "unused-suppression"}, nil, ModeWarn, nil) {
+		findings = append(findings, fmt.Sprintf(":%d: %s", finding.Start.Line, finding.Message))
+	}
+	want := []string{
+		`:2: The suppression comment for "no-effect" doesn't suppress any warnings.`,
+		`:7: The suppression comment for "print" doesn't suppress any warnings.`,
+		`:10: The suppression comment for "no-effect" doesn't suppress any warnings.`,
+		`:12: The suppression comment for "no-effect" doesn't suppress any warnings.`,
+		`:14: The suppression comment for "print" doesn't suppress any warnings.`,
+	}
+	if strings.Join(findings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got findings:\n%s\nwant:\n%s", strings.Join(findings, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestUnusedSuppressionWithoutFileReader(t *testing.T) {
+	input := `# buildifier: disable=deprecated-function
+x = 1
+`
+	warnings := []string{"deprecated-function", "unused-suppression"}
+	for _, tc := range []struct {
+		fileReader *FileReader
+		want       []string
+	}{
+		// The warning can't check other files, its suppressions may still be needed
+		{nil, nil},
+		{NewFileReader(func(string) ([]byte, error) { return nil, os.ErrNotExist }), []string{
+			`:1: The suppression comment for "deprecated-function" doesn't suppress any warnings.`,
+		}},
+	} {
+		f, err := build.ParseBzl("test/package/test_file.bzl", []byte(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var findings []string
+		for _, finding := range FileWarnings(f, warnings, nil, ModeWarn, tc.fileReader) {
+			findings = append(findings, fmt.Sprintf(":%d: %s", finding.Start.Line, finding.Message))
+		}
+		if strings.Join(findings, "\n") != strings.Join(tc.want, "\n") {
+			t.Errorf("got findings:\n%s\nwant:\n%s", strings.Join(findings, "\n"), strings.Join(tc.want, "\n"))
+		}
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Warnings about dependencies on targets that are not visible
+
+package warn
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/labels"
+)
+
+// visibilityFixCommands is whether the "target-visibility" warning should suggest buildozer
+// commands that fix the violations.
+var visibilityFixCommands = false
+
+// SetVisibilityFixCommands enables or disables buildozer commands that fix the violations
+// in the messages of the "target-visibility" warning.
+func SetVisibilityFixCommands(enabled bool) {
+	visibilityFixCommands = enabled
+}
+
+// visibilityChecker checks whether targets are visible from packages, reading the BUILD files
+// that declare the targets and the package groups used in their visibility.
+type visibilityChecker struct {
+	fileReader *FileReader
+	visiting   map[labels.Label]bool // package groups being expanded, guards against cyclic includes
+}
+
+// stringList returns the values of a list of string literals, or false if the expression
+// is not such a list.
+func stringList(expr build.Expr) ([]string, bool) {
+	list, ok := expr.(*build.ListExpr)
+	if !ok {
+		return nil, false
+	}
+	var values []string
+	for _, item := range list.List {
+		str, ok := item.(*build.StringExpr)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, str.Value)
+	}
+	return values, true
+}
+
+// visibility returns the effective visibility of a rule: the value of its `visibility`
+// attribute or the default visibility of the package. Returns false if the visibility
+// can't be determined statically.
+func (pt *packageTargets) visibility(rule *build.Rule) ([]string, bool) {
+	if expr := rule.Attr("visibility"); expr != nil {
+		return stringList(expr)
+	}
+	if pt.defaultVisibility != nil {
+		return stringList(pt.defaultVisibility)
+	}
+	return []string{"//visibility:private"}, true
+}
+
+// matchesPackageSpec returns whether a package matches a package specification of a
+// package group, such as "//foo", "//foo/..." or "public". Negations are not handled here.
+func matchesPackageSpec(spec, pkg string) bool {
+	switch spec {
+	case "public", "//...":
+		return true
+	case "private":
+		return false
+	}
+	if !strings.HasPrefix(spec, "//") {
+		// Packages of other repositories
+		return false
+	}
+	spec = strings.TrimPrefix(spec, "//")
+	if strings.HasSuffix(spec, "/...") {
+		spec = strings.TrimSuffix(spec, "/...")
+		return pkg == spec || strings.HasPrefix(pkg, spec+"/")
+	}
+	return pkg == spec
+}
+
+// groupContains returns whether a package group contains the package. The second returned
+// value is false if the package group or the groups it includes can't be read.
+func (vc *visibilityChecker) groupContains(group labels.Label, pkg string) (bool, bool) {
+	if vc.visiting[group] {
+		return false, true
+	}
+	vc.visiting[group] = true
+	defer delete(vc.visiting, group)
+
+	pt := vc.fileReader.packageTargets(group.Package)
+	if pt == nil {
+		return false, false
+	}
+	rule, ok := pt.rules[group.Target]
+	if !ok || rule.Kind() != "package_group" {
+		return false, false
+	}
+	specs, ok := stringList(rule.Attr("packages"))
+	if rule.Attr("packages") != nil && !ok {
+		return false, false
+	}
+	contains := false
+	for _, spec := range specs {
+		if strings.HasPrefix(spec, "-") {
+			if matchesPackageSpec(spec[1:], pkg) {
+				return false, true
+			}
+		} else if matchesPackageSpec(spec, pkg) {
+			contains = true
+		}
+	}
+	if contains {
+		return true, true
+	}
+
+	includes, ok := stringList(rule.Attr("includes"))
+	if rule.Attr("includes") != nil && !ok {
+		return false, false
+	}
+	for _, include := range includes {
+		label := vc.fileReader.resolveLabel(include, group.Package)
+		if contains, known := vc.groupContains(label, pkg); !known || contains {
+			return contains, known
+		}
+	}
+	return false, true
+}
+
+// isVisible returns whether a target with the given visibility declared in `targetPkg` is
+// visible from `pkg`. The second returned value is false if it can't be determined.
+func (vc *visibilityChecker) isVisible(visibility []string, targetPkg, pkg string) (bool, bool) {
+	if targetPkg == pkg {
+		return true, true
+	}
+	for _, spec := range visibility {
+		label := vc.fileReader.resolveLabel(spec, targetPkg)
+		if label.Repository != "" {
+			continue
+		}
+		switch {
+		case label.Package == "visibility" && label.Target == "public":
+			return true, true
+		case label.Package == "visibility" && label.Target == "private":
+			continue
+		case label.Target == "__pkg__":
+			if pkg == label.Package {
+				return true, true
+			}
+		case label.Target == "__subpackages__":
+			if label.Package == "" || pkg == label.Package || strings.HasPrefix(pkg, label.Package+"/") {
+				return true, true
+			}
+		default:
+			if contains, known := vc.groupContains(label, pkg); !known || contains {
+				return contains, known
+			}
+		}
+	}
+	return false, true
+}
+
+// visibilityFixCommand returns a buildozer command that makes the target visible from the package.
+func visibilityFixCommand(rule *build.Rule, visibility []string, target labels.Label, pkg string) string {
+	spec := labels.Label{Package: pkg, Target: "__pkg__"}.Format()
+	if rule.Attr("visibility") != nil {
+		return fmt.Sprintf("buildozer 'add visibility %s' %s", spec, target.Format())
+	}
+	// An explicit visibility replaces the default visibility of the package, keep its values
+	var values []string
+	for _, v := range visibility {
+		if v != "//visibility:private" {
+			values = append(values, v)
+		}
+	}
+	values = append(values, spec)
+	return fmt.Sprintf("buildozer 'set visibility %s' %s", strings.Join(values, " "), target.Format())
+}
+
+func targetVisibilityWarning(f *build.File, fileReader *FileReader) []*LinterFinding {
+	if f.Type != build.TypeBuild || fileReader == nil {
+		return nil
+	}
+
+	var findings []*LinterFinding
+	vc := visibilityChecker{
+		fileReader: fileReader,
+		visiting:   make(map[labels.Label]bool),
+	}
+	for _, rule := range f.Rules("") {
+		forEachLabel(rule, f.Pkg, fileReader, func(attr string, str *build.StringExpr, label labels.Label) {
+			if label.Package == f.Pkg {
+				return
+			}
+			pt := fileReader.packageTargets(label.Package)
+			if pt == nil {
+				return
+			}
+			target, ok := pt.rules[label.Target]
+			if !ok {
+				// Source files, generated targets, etc.
+				return
+			}
+			visibility, ok := pt.visibility(target)
+			if !ok {
+				return
+			}
+			if visible, known := vc.isVisible(visibility, label.Package, f.Pkg); visible || !known {
+				return
+			}
+
+			message := fmt.Sprintf("The target %q is not visible from the package %q.", label.Format(), "//"+f.Pkg)
+			if visibilityFixCommands {
+				message += fmt.Sprintf(" Fix it with: %s", visibilityFixCommand(target, visibility, label, f.Pkg))
+			}
+			findings = append(findings, makeLinterFinding(str, message))
+		})
+	}
+	return findings
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warn
+
+import "testing"
+
+var visibilityTestFiles = map[string]string{
+	"lib/BUILD": `
+package(default_visibility = ["//visibility:private"])
+
+cc_library(name = "private")
+
+cc_library(
+    name = "public",
+    visibility = ["//visibility:public"],
+)
+
+cc_library(
+    name = "pkg",
+    visibility = ["//test/package:__pkg__"],
+)
+
+cc_library(
+    name = "subpackages",
+    visibility = ["//test:__subpackages__"],
+)
+
+cc_library(
+    name = "other",
+    visibility = ["//other:__pkg__"],
+)
+
+cc_library(
+    name = "group",
+    visibility = ["//groups:friends"],
+)
+
+cc_library(
+    name = "excluded",
+    visibility = ["//groups:all_but_test"],
+)
+
+cc_library(
+    name = "unknown",
+    visibility = VISIBILITY,
+)
+`,
+	"default/BUILD": `
+package(default_visibility = ["//other:__pkg__"])
+
+cc_library(name = "default")
+`,
+	"groups/BUILD": `
+package_group(
+    name = "friends",
+    includes = [":test"],
+)
+
+package_group(
+    name = "test",
+    packages = ["//test/..."],
+    includes = [":friends"],
+)
+
+package_group(
+    name = "all_but_test",
+    packages = [
+        "//...",
+        "-//test/package",
+    ],
+)
+`,
+}
+
+func TestTargetVisibility(t *testing.T) {
+	defer setUpFileReader(visibilityTestFiles)()
+
+	checkFindings(t, "target-visibility", `
+cc_library(
+    name = "foo",
+    deps = [
+        ":bar",
+        "//lib:private",
+        "//lib:public",
+        "//lib:pkg",
+        "//lib:subpackages",
+        "//lib:other",
+        "//lib:group",
+        "//lib:excluded",
+        "//lib:unknown",
+        "//lib:source.cc",
+        "//default",
+        "//missing:target",
+    ],
+)
+`,
+		[]string{
+			`:5: The target "//lib:private" is not visible from the package "//test/package".`,
+			`:9: The target "//lib:other" is not visible from the package "//test/package".`,
+			`:11: The target "//lib:excluded" is not visible from the package "//test/package".`,
+			`:14: The target "//default" is not visible from the package "//test/package".`,
+		},
+		scopeBuild)
+}
+
+func TestTargetVisibilityFixCommands(t *testing.T) {
+	defer setUpFileReader(visibilityTestFiles)()
+	SetVisibilityFixCommands(true)
+	defer SetVisibilityFixCommands(false)
+
+	checkFindings(t, "target-visibility", `
+cc_library(
+    name = "foo",
+    deps = [
+        "//lib:private",
+        "//lib:other",
+        "//default",
+    ],
+)
+`,
+		[]string{
+			`:4: The target "//lib:private" is not visible from the package "//test/package". Fix it with: buildozer 'set visibility //test/package:__pkg__' //lib:private`,
+			`:5: The target "//lib:other" is not visible from the package "//test/package". Fix it with: buildozer 'add visibility //test/package:__pkg__' //lib:other`,
+			`:6: The target "//default" is not visible from the package "//test/package". Fix it with: buildozer 'set visibility //other:__pkg__ //test/package:__pkg__' //default`,
+		},
+		scopeBuild)
+}
@@ -19,6 +19,7 @@ package warn
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
 
@@ -54,7 +55,7 @@ func setUpFileReader(data map[string]string) (cleanup func()) {
 		if contents, ok := data[filename]; ok {
 			return []byte(contents), nil
 		}
-		return nil, fmt.Errorf("file not found")
+		return nil, &os.PathError{Op: "open", Path: filename, Err: os.ErrNotExist}
 	}
 	testFileReader = NewFileReader(readFile)
 	fileReaderRequests = nil
@@ -104,6 +105,17 @@ func getFileForTest(input string, fileType build.FileType) *build.File {
 	return file
 }
 
+// fixWarnings applies the fixes of several warning categories to a .bzl file and returns
+// the fixed content and the remaining findings as "<category>:<line>".
+func fixWarnings(categories []string, input string) (string, []string) {
+	file := getFileForTest(input, build.TypeBzl)
+	var findings []string
+	for _, w := range FileWarnings(file, categories, nil, ModeFix, testFileReader) {
+		findings = append(findings, fmt.Sprintf("%s:%d", w.Category, w.Start.Line))
+	}
+	return string(build.Format(file)), findings
+}
+
 func getFindings(category, input string, fileType build.FileType) []*Finding {
 	file := getFileForTest(input, fileType)
 	return FileWarnings(file, []string{category}, nil, ModeWarn, testFileReader)
@@ -379,6 +391,12 @@ cc_library(
 			end:      5,
 			category: "print",
 		},
+		{
+			// Comments are case-insensitive
+			start:    5,
+			end:      5,
+			category: "Print",
+		},
 		{
 			start:    7,
 			end:      7,
@@ -418,3 +436,213 @@ cc_library(
 		}
 	}
 }
+
+func TestDisabledWarningDirectives(t *testing.T) {
+	contents := `# Copyright
+# buildifier: disable-file=print
+
+load(":foo.bzl", "foo")
+
+# buildifier: disable-file=no-effect
+# buildifier: disable-next-line=positional-args
+foo("a")
+foo("b")
+
+# buildifier: disable-start=unused-variable
+x = 1
+y = 2
+# buildifier: disable-end=unused-variable
+
+# buildozer: disable-start=uninitialized
+z = w
+`
+
+	f, err := build.ParseBzl("file.bzl", []byte(contents))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	tests := []struct {
+		start    int
+		end      int
+		category string
+	}{
+		{
+			start:    1,
+			end:      18,
+			category: "print",
+		},
+		{
+			start:    8,
+			end:      8,
+			category: "positional-args",
+		},
+		{
+			start:    11,
+			end:      14,
+			category: "unused-variable",
+		},
+		{
+			start:    16,
+			end:      18,
+			category: "uninitialized",
+		},
+		{
+			start:    0,
+			end:      -1,
+			category: "no-effect",
+		},
+	}
+
+	linesCount := strings.Count(contents, "\n")
+
+	for _, tc := range tests {
+		for line := 1; line <= linesCount; line++ {
+			disabled := DisabledWarning(f, line, tc.category)
+			shouldBeDisabled := line >= tc.start && line <= tc.end
+			if disabled != shouldBeDisabled {
+				t.Errorf("Wrong disabled status for the category %q on line %d, want %t, got %t", tc.category, line, shouldBeDisabled, disabled)
+			}
+		}
+	}
+}
+
+// walkDisabledWarning is the reference implementation of DisabledWarning for `disable=` comments,
+// it walks the whole syntax tree for every lookup.
+func walkDisabledWarning(f *build.File, findingLine int, warning string) bool {
+	disabled := false
+	build.Walk(f, func(expr build.Expr, stack []build.Expr) {
+		start, end := expr.Span()
+		comments := expr.Comment()
+		if len(comments.Before) > 0 {
+			start, _ = comments.Before[0].Span()
+		}
+		if len(comments.After) > 0 {
+			_, end = comments.After[len(comments.After)-1].Span()
+		}
+		if findingLine >= start.Line && findingLine <= end.Line && HasDisablingComment(expr, warning) {
+			disabled = true
+		}
+	})
+	return disabled
+}
+
+func TestHasDisablingComment(t *testing.T) {
+	f, err := build.ParseBuild("BUILD", []byte(`foo()  # Buildifier: Disable=Print
+`))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	for _, tc := range []struct {
+		warning string
+		want    bool
+	}{
+		{"print", true},
+		{"Print", true},
+		{"PRINT", true},
+		{"no-effect", false},
+	} {
+		if got := HasDisablingComment(f.Stmt[0], tc.warning); got != tc.want {
+			t.Errorf("HasDisablingComment(%q) = %t, want %t", tc.warning, got, tc.want)
+		}
+		if got := DisabledWarning(f, 1, tc.warning); got != tc.want {
+			t.Errorf("DisabledWarning(%q) = %t, want %t", tc.warning, got, tc.want)
+		}
+	}
+}
+
+func TestSuppressionIndex(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&b, `# buildifier: disable=load-on-top
+foo(
+    name = "a%d",  # buildifier: disable=positional-args
+    srcs = [
+        # buildozer: disable=print
+        "b",
+        "c",  # buildifier: disable=no-effect
+    ] + [x for x in y],  # buildifier: disable=unused-variable
+)
+
+def f%d():
+    # buildifier: disable=no-effect
+    if x:
+        pass  # buildifier: disable=print
+
+    # buildifier: disable=print
+    return y
+
+`, i, i)
+	}
+
+	f, err := build.ParseBzl("file.bzl", []byte(b.String()))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	index := newSuppressionIndex(f)
+	linesCount := strings.Count(b.String(), "\n")
+	for _, category := range []string{"load", "load-on-top", "positional-args", "print", "Print", "no-effect", "NO-EFFECT", "unused-variable", "other"} {
+		for line := 0; line <= linesCount+1; line++ {
+			want := walkDisabledWarning(f, line, category)
+			if got := len(index.disablingComments(line, category)) > 0; got != want {
+				t.Errorf("Wrong disabled status for the category %q on line %d, want %t, got %t", category, line, want, got)
+			}
+		}
+	}
+}
+
+func TestSuppressionIndexAfterRemovingStatements(t *testing.T) {
+	// The unused load statement is replaced with nil by the fix
+	fixed, findings := fixWarnings([]string{"load", "module-docstring"}, `
+load(":a.bzl", "unused")
+
+def f():
+    pass
+`)
+	want := `def f():
+    pass
+`
+	if fixed != want {
+		t.Errorf("fixWarnings() = %q, want %q", fixed, want)
+	}
+	if got := strings.Join(findings, ", "); got != "module-docstring:3" {
+		t.Errorf("fixWarnings() findings = %q, want %q", got, "module-docstring:3")
+	}
+}
+
+func TestSuppressionsInvalidatedByFixes(t *testing.T) {
+	// The suppressions are collected again after the unused load statement is removed
+	fixed, findings := fixWarnings([]string{"load", "print"}, `
+load(":a.bzl", "unused")
+
+def f():
+    print("a")  # buildifier: disable=print
+
+    # buildifier: disable-next-line=print
+    print("b")
+
+    # buildifier: disable-start=print
+    print("c")
+
+    # buildifier: disable-end=print
+    print("d")
+`)
+	want := `def f():
+    print("a")  # buildifier: disable=print
+
+    # buildifier: disable-next-line=print
+    print("b")
+
+    # buildifier: disable-start=print
+    print("c")
+
+    # buildifier: disable-end=print
+    print("d")
+`
+	if fixed != want {
+		t.Errorf("fixWarnings() = %q, want %q", fixed, want)
+	}
+	if got := strings.Join(findings, ", "); got != "print:13" {
+		t.Errorf("fixWarnings() findings = %q, want %q", got, "print:13")
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The workspace2module binary translates a WORKSPACE file into a draft of
+// a MODULE.bazel file and lists the statements it can't translate.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/wspace"
+)
+
+var (
+	mappingFile = flag.String("mapping", "", "JSON file mapping WORKSPACE repository names to Bazel modules, "+
+		`e.g. {"io_bazel_rules_go": {"name": "rules_go", "version": "0.41.0"}}`)
+	outputFile = flag.String("output", "", "write the MODULE.bazel draft to this file instead of stdout")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `usage: workspace2module [-mapping mapping.json] [-output MODULE.bazel] [path/to/WORKSPACE]
+
+If the path to the WORKSPACE file is omitted, the WORKSPACE file of the current workspace is used.
+
+`)
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	var filename string
+	switch len(flag.Args()) {
+	case 0:
+		root, _ := wspace.FindWorkspaceRoot("")
+		if root == "" {
+			fmt.Fprintln(os.Stderr, "workspace2module: not in a workspace, please specify the WORKSPACE file")
+			os.Exit(2)
+		}
+		filename = filepath.Join(root, "WORKSPACE")
+		if _, err := os.Stat(filename + ".bazel"); err == nil {
+			filename += ".bazel"
+		}
+	case 1:
+		filename = flag.Args()[0]
+	default:
+		usage()
+	}
+
+	mapping := make(map[string]wspace.ModuleMapping)
+	if *mappingFile != "" {
+		data, err := ioutil.ReadFile(*mappingFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "workspace2module: %v\n", err)
+			os.Exit(2)
+		}
+		if err := json.Unmarshal(data, &mapping); err != nil {
+			fmt.Fprintf(os.Stderr, "workspace2module: can't parse %s: %v\n", *mappingFile, err)
+			os.Exit(2)
+		}
+	}
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "workspace2module: %v\n", err)
+		os.Exit(2)
+	}
+	ws, err := build.ParseWorkspace(filename, data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "workspace2module: %v\n", err)
+		os.Exit(2)
+	}
+
+	module, untranslated := wspace.MigrateWorkspace(ws, mapping)
+	output := build.Format(module)
+	if *outputFile == "" {
+		os.Stdout.Write(output)
+	} else if err := ioutil.WriteFile(*outputFile, output, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "workspace2module: %v\n", err)
+		os.Exit(2)
+	}
+
+	for _, u := range untranslated {
+		fmt.Fprintf(os.Stderr, "%s:%d: %s\n", filename, u.Line, u.Message)
+	}
+	if len(untranslated) > 0 {
+		os.Exit(1)
+	}
+}
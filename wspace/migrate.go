@@ -0,0 +1,203 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Translation of WORKSPACE files to MODULE.bazel files.
+
+package wspace
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bazelbuild/buildtools/build"
+)
+
+// ModuleMapping describes a Bazel module that should replace an external repository
+// declared in a WORKSPACE file.
+type ModuleMapping struct {
+	Name    string `json:"name"`    // name of the module in the registry
+	Version string `json:"version"` // version of the module
+}
+
+// Untranslated describes a WORKSPACE statement that can't be translated automatically.
+type Untranslated struct {
+	Line    int
+	Message string
+}
+
+// migratedRepoRules are the repository rules that can be declared in MODULE.bazel files
+// with `use_repo_rule`, by the files they're defined in.
+var migratedRepoRules = map[string]string{
+	"git_repository":     "@bazel_tools//tools/build_defs/repo:git.bzl",
+	"http_archive":       "@bazel_tools//tools/build_defs/repo:http.bzl",
+	"http_file":          "@bazel_tools//tools/build_defs/repo:http.bzl",
+	"http_jar":           "@bazel_tools//tools/build_defs/repo:http.bzl",
+	"new_git_repository": "@bazel_tools//tools/build_defs/repo:git.bzl",
+}
+
+// migratedFunctions are the WORKSPACE functions that have the same signature in MODULE.bazel files.
+var migratedFunctions = map[string]bool{
+	"register_execution_platforms": true,
+	"register_toolchains":          true,
+}
+
+// MigrateWorkspace translates a WORKSPACE file into a draft of a MODULE.bazel file.
+// Repositories listed in `mapping` (by their names in WORKSPACE) become `bazel_dep`
+// declarations, other repositories declared with known repository rules (such as `http_archive`)
+// are declared with `use_repo_rule`. All statements that can't be translated
+// (e.g. calls to macros or repository rules with non-literal arguments) are returned
+// with their line numbers. Load statements are always omitted.
+func MigrateWorkspace(ws *build.File, mapping map[string]ModuleMapping) (*build.File, []Untranslated) {
+	var module *build.CallExpr
+	var deps, repos, registrations []build.Expr
+	var untranslated []Untranslated
+	seenDeps := make(map[string]bool)
+	usedRepoRules := make(map[string]bool)
+
+	for _, stmt := range ws.Stmt {
+		switch stmt.(type) {
+		case *build.LoadStmt, *build.CommentBlock:
+			continue
+		}
+		start, _ := stmt.Span()
+		call, ok := stmt.(*build.CallExpr)
+		if !ok {
+			untranslated = append(untranslated, Untranslated{start.Line, "the statement can't be translated"})
+			continue
+		}
+		rule := build.NewRule(call)
+		kind, name := rule.Kind(), rule.Name()
+
+		if m, ok := mapping[name]; ok && name != "" {
+			if seenDeps[m.Name] {
+				continue
+			}
+			seenDeps[m.Name] = true
+			dep := newCall("bazel_dep")
+			dep.SetAttr("name", &build.StringExpr{Value: m.Name})
+			if m.Version != "" {
+				dep.SetAttr("version", &build.StringExpr{Value: m.Version})
+			}
+			if name != m.Name {
+				dep.SetAttr("repo_name", &build.StringExpr{Value: name})
+			}
+			deps = append(deps, dep.Call)
+			continue
+		}
+
+		switch {
+		case kind == "workspace" && module == nil:
+			module = newCall("module").Call
+			if name != "" {
+				module.List = []build.Expr{&build.AssignExpr{
+					LHS: &build.Ident{Name: "name"},
+					Op:  "=",
+					RHS: &build.StringExpr{Value: name},
+				}}
+			}
+		case migratedFunctions[kind]:
+			registrations = append(registrations, call)
+		case migratedRepoRules[kind] != "":
+			if name == "" || !hasLiteralArgs(call) {
+				untranslated = append(untranslated, Untranslated{start.Line,
+					fmt.Sprintf("%q has non-literal arguments", kind)})
+				continue
+			}
+			usedRepoRules[kind] = true
+			repos = append(repos, call)
+		default:
+			message := fmt.Sprintf("%q is not a known repository rule", kind)
+			if name != "" {
+				message = fmt.Sprintf("%q (repository %q) is not a known repository rule", kind, name)
+			}
+			untranslated = append(untranslated, Untranslated{start.Line, message})
+		}
+	}
+
+	f := &build.File{Type: build.TypeModule}
+	if module != nil {
+		f.Stmt = append(f.Stmt, module)
+	}
+	f.Stmt = append(f.Stmt, deps...)
+
+	var kinds []string
+	for kind := range usedRepoRules {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		useRepoRule := newCall("use_repo_rule")
+		useRepoRule.Call.List = []build.Expr{
+			&build.StringExpr{Value: migratedRepoRules[kind]},
+			&build.StringExpr{Value: kind},
+		}
+		useRepoRule.Call.ForceCompact = true
+		f.Stmt = append(f.Stmt, &build.AssignExpr{
+			LHS: &build.Ident{Name: kind},
+			Op:  "=",
+			RHS: useRepoRule.Call,
+		})
+	}
+	f.Stmt = append(f.Stmt, repos...)
+	f.Stmt = append(f.Stmt, registrations...)
+	return f, untranslated
+}
+
+// newCall creates a call of a function without arguments.
+func newCall(kind string) *build.Rule {
+	return build.NewRule(&build.CallExpr{X: &build.Ident{Name: kind}})
+}
+
+// hasLiteralArgs checks whether all arguments of a call are keyword arguments with
+// values that don't depend on variables or function calls.
+func hasLiteralArgs(call *build.CallExpr) bool {
+	for _, arg := range call.List {
+		assign, ok := arg.(*build.AssignExpr)
+		if !ok || !isLiteral(assign.RHS) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLiteral checks whether an expression consists only of literals.
+func isLiteral(expr build.Expr) bool {
+	switch expr := expr.(type) {
+	case *build.StringExpr, *build.LiteralExpr:
+		return true
+	case *build.Ident:
+		return expr.Name == "True" || expr.Name == "False" || expr.Name == "None"
+	case *build.ListExpr:
+		for _, x := range expr.List {
+			if !isLiteral(x) {
+				return false
+			}
+		}
+		return true
+	case *build.DictExpr:
+		for _, kv := range expr.List {
+			if !isLiteral(kv.Key) || !isLiteral(kv.Value) {
+				return false
+			}
+		}
+		return true
+	case *build.BinaryExpr:
+		return (expr.Op == "+" || expr.Op == "%") && isLiteral(expr.X) && isLiteral(expr.Y)
+	case *build.ParenExpr:
+		return isLiteral(expr.X)
+	}
+	return false
+}
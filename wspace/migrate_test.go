@@ -0,0 +1,123 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wspace
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bazelbuild/buildtools/build"
+)
+
+func TestMigrateWorkspace(t *testing.T) {
+	input := `workspace(name = "my_project")
+
+load("@bazel_tools//tools/build_defs/repo:http.bzl", "http_archive")
+load("@bazel_tools//tools/build_defs/repo:git.bzl", "git_repository")
+
+http_archive(
+    name = "io_bazel_rules_go",
+    sha256 = "abcdef",
+    urls = ["https://example.com/rules_go.zip"],
+)
+
+http_archive(
+    name = "bazel_skylib",
+    urls = ["https://example.com/skylib.zip"],
+)
+
+# A library without a module
+http_archive(
+    name = "foo",
+    build_file = "//third_party:foo.BUILD",
+    strip_prefix = "foo-1.0",
+    urls = ["https://example.com/foo-%s.zip" % "1.0"],
+)
+
+git_repository(
+    name = "bar",
+    commit = "1234",
+    remote = "https://example.com/bar.git",
+)
+
+VERSION = "2.0"
+
+http_archive(
+    name = "baz",
+    urls = ["https://example.com/baz-%s.zip" % VERSION],
+)
+
+load("@io_bazel_rules_go//go:deps.bzl", "go_rules_dependencies")
+
+go_rules_dependencies()
+
+maven_install(
+    name = "maven",
+    artifacts = ["com.google.guava:guava:31.0-jre"],
+)
+
+register_toolchains("//toolchains:all")
+`
+	expected := `module(name = "my_project")
+
+bazel_dep(name = "rules_go", repo_name = "io_bazel_rules_go", version = "0.41.0")
+bazel_dep(name = "bazel_skylib", version = "1.4.2")
+
+git_repository = use_repo_rule("@bazel_tools//tools/build_defs/repo:git.bzl", "git_repository")
+
+http_archive = use_repo_rule("@bazel_tools//tools/build_defs/repo:http.bzl", "http_archive")
+
+# A library without a module
+http_archive(
+    name = "foo",
+    build_file = "//third_party:foo.BUILD",
+    strip_prefix = "foo-1.0",
+    urls = ["https://example.com/foo-%s.zip" % "1.0"],
+)
+
+git_repository(
+    name = "bar",
+    commit = "1234",
+    remote = "https://example.com/bar.git",
+)
+
+register_toolchains("//toolchains:all")
+`
+	expectedUntranslated := []Untranslated{
+		{31, "the statement can't be translated"},
+		{33, `"http_archive" has non-literal arguments`},
+		{40, `"go_rules_dependencies" is not a known repository rule`},
+		{42, `"maven_install" (repository "maven") is not a known repository rule`},
+	}
+
+	ws, err := build.ParseWorkspace("WORKSPACE", []byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapping := map[string]ModuleMapping{
+		"io_bazel_rules_go": {Name: "rules_go", Version: "0.41.0"},
+		"bazel_skylib":      {Name: "bazel_skylib", Version: "1.4.2"},
+	}
+	module, untranslated := MigrateWorkspace(ws, mapping)
+
+	if output := string(build.Format(module)); output != expected {
+		t.Errorf("MigrateWorkspace() = %s\nwant %s", output, expected)
+	}
+	if !reflect.DeepEqual(untranslated, expectedUntranslated) {
+		t.Errorf("MigrateWorkspace() untranslated = %v; want %v", untranslated, expectedUntranslated)
+	}
+}
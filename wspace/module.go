@@ -0,0 +1,199 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Information about the root module extracted from MODULE.bazel.
+
+package wspace
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/bazelbuild/buildtools/build"
+	"github.com/bazelbuild/buildtools/labels"
+)
+
+// Module describes the root module of a Bzlmod workspace as declared in MODULE.bazel.
+type Module struct {
+	Name     string // name of the module, can be empty if there's no module() call
+	RepoName string // name of the main repository as seen from the module itself
+	// Repos maps the apparent names of the repositories visible from the main repository
+	// to their canonical names, e.g. "go_sdk" -> "rules_go++go_sdk+go_sdk".
+	// The main repository itself is mapped to the empty canonical name.
+	Repos map[string]string
+}
+
+// RepoMapping returns the repository mapping of the main repository.
+// Returns nil for a nil module.
+func (m *Module) RepoMapping() *labels.RepoMapping {
+	if m == nil {
+		return nil
+	}
+	return labels.NewRepoMapping(m.Repos)
+}
+
+// builtinRepos are the repositories that are visible from every module without being declared.
+var builtinRepos = []string{"bazel_tools", "local_config_platform"}
+
+// FindModule parses the MODULE.bazel file located in the workspace root `root`.
+// Returns nil if the file doesn't exist or can't be parsed.
+func FindModule(root string) *Module {
+	filename := filepath.Join(root, moduleFile)
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil
+	}
+	f, err := build.ParseModule(filename, data)
+	if err != nil {
+		return nil
+	}
+	return ParseModule(f)
+}
+
+// ParseModule extracts the module name and the repository mapping of the main repository
+// from a MODULE.bazel file. It recognizes `module`, `bazel_dep`, `use_extension`,
+// `use_repo` and `use_repo_rule` calls with literal arguments.
+func ParseModule(f *build.File) *Module {
+	m := &Module{Repos: make(map[string]string)}
+	for _, repo := range builtinRepos {
+		m.Repos[repo] = repo
+	}
+
+	extensions := make(map[string]string) // extension proxy variables -> canonical prefixes of repos they create
+	repoRules := make(map[string]bool)    // repository rules imported with use_repo_rule
+
+	for _, stmt := range f.Stmt {
+		if assign, ok := stmt.(*build.AssignExpr); ok {
+			lhs, ok := assign.LHS.(*build.Ident)
+			if !ok {
+				continue
+			}
+			call, ok := assign.RHS.(*build.CallExpr)
+			if !ok {
+				continue
+			}
+			switch build.NewRule(call).Kind() {
+			case "use_extension":
+				if prefix, ok := extensionPrefix(call, m.Repos); ok {
+					extensions[lhs.Name] = prefix
+				}
+			case "use_repo_rule":
+				repoRules[lhs.Name] = true
+			}
+			continue
+		}
+
+		call, ok := stmt.(*build.CallExpr)
+		if !ok {
+			continue
+		}
+		rule := build.NewRule(call)
+		switch kind := rule.Kind(); kind {
+		case "module":
+			m.Name = rule.AttrString("name")
+			m.RepoName = rule.AttrString("repo_name")
+			if m.RepoName == "" {
+				m.RepoName = m.Name
+			}
+			if m.RepoName != "" {
+				m.Repos[m.RepoName] = ""
+			}
+		case "bazel_dep":
+			name := rule.AttrString("name")
+			if name == "" {
+				continue
+			}
+			apparent := rule.AttrString("repo_name")
+			if apparent == "" {
+				apparent = name
+			}
+			m.Repos[apparent] = name + "+"
+		case "use_repo":
+			if len(call.List) == 0 {
+				continue
+			}
+			proxy, ok := call.List[0].(*build.Ident)
+			if !ok {
+				continue
+			}
+			prefix, ok := extensions[proxy.Name]
+			if !ok {
+				continue
+			}
+			for _, arg := range call.List[1:] {
+				switch arg := arg.(type) {
+				case *build.StringExpr:
+					m.Repos[arg.Value] = prefix + arg.Value
+				case *build.AssignExpr:
+					// use_repo(ext, apparent_name = "repo_name")
+					lhs, ok := arg.LHS.(*build.Ident)
+					if !ok {
+						continue
+					}
+					if value, ok := arg.RHS.(*build.StringExpr); ok {
+						m.Repos[lhs.Name] = prefix + value.Value
+					}
+				}
+			}
+		default:
+			if !repoRules[kind] {
+				continue
+			}
+			if name := rule.AttrString("name"); name != "" {
+				m.Repos[name] = "+_repo_rules+" + name
+			}
+		}
+	}
+	return m
+}
+
+// extensionPrefix returns the common prefix of canonical names of repositories created by
+// the module extension declared by a `use_extension` call, e.g. for
+// `use_extension("@rules_go//go:extensions.bzl", "go_sdk")` it's "rules_go++go_sdk+".
+func extensionPrefix(call *build.CallExpr, repos map[string]string) (string, bool) {
+	if len(call.List) < 2 {
+		return "", false
+	}
+	file, ok := call.List[0].(*build.StringExpr)
+	if !ok {
+		return "", false
+	}
+	name, ok := call.List[1].(*build.StringExpr)
+	if !ok {
+		return "", false
+	}
+
+	// The canonical name of the repository where the extension is defined
+	canonical := ""
+	if strings.HasPrefix(file.Value, "@@") {
+		canonical = strings.TrimPrefix(file.Value, "@@")
+		if i := strings.Index(canonical, "//"); i >= 0 {
+			canonical = canonical[:i]
+		}
+	} else if strings.HasPrefix(file.Value, "@") && !strings.HasPrefix(file.Value, "@//") {
+		apparent := strings.TrimPrefix(file.Value, "@")
+		if i := strings.Index(apparent, "//"); i >= 0 {
+			apparent = apparent[:i]
+		}
+		repo, ok := repos[apparent]
+		if !ok {
+			return "", false
+		}
+		canonical = repo
+	}
+	return canonical + "+" + name.Value + "+", true
+}
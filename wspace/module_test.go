@@ -0,0 +1,95 @@
+/*
+Copyright 2021 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBasicModule(t *testing.T) {
+	runBasicTestWithRepoRootFile(t, moduleFile)
+	runBasicTestWithRepoRootFile(t, repoFile)
+}
+
+func TestFindModule(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if m := FindModule(tmp); m != nil {
+		t.Errorf("FindModule() = %v for a directory without MODULE.bazel, want nil", m)
+	}
+
+	module := []byte(`
+module(name = "my_module", version = "1.0")
+
+bazel_dep(name = "rules_go", version = "0.40.0")
+bazel_dep(name = "protobuf", version = "21.7", repo_name = "com_google_protobuf")
+bazel_dep(name = "gazelle", dev_dependency = True)
+
+go_sdk = use_extension("@rules_go//go:extensions.bzl", "go_sdk")
+use_repo(go_sdk, "go_toolchains", sdk = "go_default_sdk")
+
+local = use_extension("//:extensions.bzl", "local_ext")
+use_repo(local, "local_repo")
+
+unknown = use_extension("@unknown//:extensions.bzl", "ext")
+use_repo(unknown, "unknown_repo")
+
+http_archive = use_repo_rule("@bazel_tools//tools/build_defs/repo:http.bzl", "http_archive")
+http_archive(name = "zlib", urls = ["https://example.com/zlib.tar.gz"])
+`)
+	if err := ioutil.WriteFile(filepath.Join(tmp, moduleFile), module, 0755); err != nil {
+		t.Fatal(err)
+	}
+	m := FindModule(tmp)
+	if m == nil {
+		t.Fatal("FindModule() = nil, want a module")
+	}
+	if m.Name != "my_module" || m.RepoName != "my_module" {
+		t.Errorf("FindModule() = {Name: %q, RepoName: %q}, want {Name: %q, RepoName: %q}", m.Name, m.RepoName, "my_module", "my_module")
+	}
+	expected := map[string]string{
+		"bazel_tools":           "bazel_tools",
+		"local_config_platform": "local_config_platform",
+		"my_module":             "",
+		"rules_go":              "rules_go+",
+		"com_google_protobuf":   "protobuf+",
+		"gazelle":               "gazelle+",
+		"go_toolchains":         "rules_go++go_sdk+go_toolchains",
+		"sdk":                   "rules_go++go_sdk+go_default_sdk",
+		"local_repo":            "+local_ext+local_repo",
+		"zlib":                  "+_repo_rules+zlib",
+	}
+	if !reflect.DeepEqual(m.Repos, expected) {
+		t.Errorf("FindModule().Repos = %q; want %q", m.Repos, expected)
+	}
+
+	mapping := m.RepoMapping()
+	if got := mapping.Shorten("@my_module//foo:bar", "foo"); got != ":bar" {
+		t.Errorf("RepoMapping().Shorten(%q) = %q, want %q", "@my_module//foo:bar", got, ":bar")
+	}
+	if !mapping.Equal("@com_google_protobuf//:protobuf", "@@protobuf+//:protobuf", "") {
+		t.Errorf("RepoMapping().Equal(%q, %q) = false, want true", "@com_google_protobuf//:protobuf", "@@protobuf+//:protobuf")
+	}
+}
@@ -27,6 +27,8 @@ import (
 )
 
 const workspaceFile = "WORKSPACE"
+const moduleFile = "MODULE.bazel"
+const repoFile = "REPO.bazel"
 const buildFile = "BUILD"
 
 func isFile(fi os.FileInfo) bool {
@@ -40,6 +42,8 @@ func isExecutable(fi os.FileInfo) bool {
 var repoRootFiles = map[string]func(os.FileInfo) bool{
 	workspaceFile:            isFile,
 	workspaceFile + ".bazel": isFile,
+	moduleFile:               isFile,
+	repoFile:                 isFile,
 	".buckconfig":            isFile,
 	"pants":                  isExecutable,
 }
@@ -59,7 +63,7 @@ func findContextPath(rootDir string) (string, error) {
 
 // FindWorkspaceRoot splits the current code context (the rootDir if present,
 // the working directory if not.) It returns the path of the directory
-// containing the WORKSPACE file, and the rest.
+// containing the WORKSPACE (or MODULE.bazel, or REPO.bazel) file, and the rest.
 func FindWorkspaceRoot(rootDir string) (root string, rest string) {
 	wd, err := findContextPath(rootDir)
 	if err != nil {
@@ -137,9 +141,9 @@ func relPath(base, target string) (string, error) {
 
 // SplitFilePath splits a file path into the workspace root, package name and label.
 // Workspace root is determined as the last directory in the file path that
-// contains a WORKSPACE (or WORKSPACE.bazel) file.
+// contains a WORKSPACE (or WORKSPACE.bazel, MODULE.bazel, REPO.bazel) file.
 // Package and label are always separated with forward slashes.
-// Returns empty strings if no WORKSPACE file is found.
+// Returns empty strings if no such file is found.
 func SplitFilePath(filename string) (workspaceRoot, pkg, label string) {
 	dir := filepath.Dir(filename)
 	workspaceRoot, err := find(dir, repoRootFiles)